@@ -130,32 +130,13 @@ func getRole(permissionIDs []primitive.ObjectID) *model.Role {
 }
 
 func getAllPermissions() []model.Permission {
-	return []model.Permission{
-		{Name: "Category read", Slug: "category-read"},
-		{Name: "Category create", Slug: "category-create"},
-		{Name: "Category edit", Slug: "category-edit"},
-		{Name: "Category delete", Slug: "category-delete"},
-
-		{Name: "Permission read", Slug: "permission-read"},
-		{Name: "Permission create", Slug: "permission-create"},
-		{Name: "Permission edit", Slug: "permission-edit"},
-		{Name: "Permission delete", Slug: "permission-delete"},
-
-		{Name: "Question read", Slug: "question-read"},
-		{Name: "Question create", Slug: "question-create"},
-		{Name: "Question edit", Slug: "question-edit"},
-		{Name: "Question delete", Slug: "question-delete"},
-
-		{Name: "Role read", Slug: "role-read"},
-		{Name: "Role create", Slug: "role-create"},
-		{Name: "Role edit", Slug: "role-edit"},
-		{Name: "Role delete", Slug: "role-delete"},
-
-		{Name: "User read", Slug: "user-read"},
-		{Name: "User create", Slug: "user-create"},
-		{Name: "User edit", Slug: "user-edit"},
-		{Name: "User delete", Slug: "user-delete"},
+	slugs := def.PermissionSlugs()
+	permissions := make([]model.Permission, 0, len(slugs))
+	for _, slug := range slugs {
+		permissions = append(permissions, model.Permission{Name: slug.Name(), Slug: slug.String()})
 	}
+
+	return permissions
 }
 
 func getAllCategories() []model.Category {
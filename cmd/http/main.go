@@ -21,9 +21,11 @@ func main() {
 	stopChan := make(chan os.Signal, 1)
 	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM)
 
+	app.Workers.Start()
+	app.Scheduler.Start()
 	go start(server, app, errChan)
 	wait(errChan, stopChan)
-	shutdown(server)
+	shutdown(server, app)
 }
 
 func setup(app *app.App) *http.Server {
@@ -50,7 +52,7 @@ func wait(errChan <-chan error, stopChan <-chan os.Signal) {
 	}
 }
 
-func shutdown(server *http.Server) {
+func shutdown(server *http.Server, app *app.App) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -59,5 +61,8 @@ func shutdown(server *http.Server) {
 		panic(err)
 	}
 
+	app.Scheduler.Stop()
+	app.Workers.Stop()
+
 	log.Println("server stopped gracefully")
 }
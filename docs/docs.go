@@ -15,7 +15,7 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/auth": {
+        "/v1/audit-logs": {
             "get": {
                 "security": [
                     {
@@ -26,22 +26,99 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "audit-logs"
+                ],
+                "summary": "audit logs list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "pagination[page]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count",
+                        "name": "pagination[count]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "created_at",
+                        "name": "sorts[created_at]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated actor ids",
+                        "name": "filters[actor_id]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "role",
+                            "user",
+                            "question"
+                        ],
+                        "type": "string",
+                        "description": "target type",
+                        "name": "filters[target_type]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated target ids",
+                        "name": "filters[target_id]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "action",
+                        "name": "filters[action]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "request id",
+                        "name": "filters[request_id]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, RFC 3339",
+                        "name": "filters[from]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or before, RFC 3339",
+                        "name": "filters[to]",
+                        "in": "query"
+                    }
                 ],
-                "summary": "get auth user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/response.success"
+                                    "$ref": "#/definitions/response.list"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.User"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.AuditLog"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/dto.Pagination"
                                         }
                                     }
                                 }
@@ -49,10 +126,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/v1/auth": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
@@ -60,18 +141,7 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "login",
-                "parameters": [
-                    {
-                        "description": "login request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.Login"
-                        }
-                    }
-                ],
+                "summary": "get auth user",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -84,7 +154,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.Token"
+                                            "$ref": "#/definitions/dto.Me"
                                         }
                                     }
                                 }
@@ -92,9 +162,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/auth/google": {
+            },
             "post": {
                 "consumes": [
                     "application/json"
@@ -105,15 +173,15 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "google login",
+                "summary": "login",
                 "parameters": [
                     {
-                        "description": "google login request",
+                        "description": "login request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.GoogleLogin"
+                            "$ref": "#/definitions/request.Login"
                         }
                     }
                 ],
@@ -135,12 +203,35 @@ const docTemplate = `{
                                 }
                             ]
                         }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Challenge"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
                 }
             }
         },
-        "/v1/auth/refresh": {
+        "/v1/auth/2fa/confirm": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -150,15 +241,15 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "refresh token",
+                "summary": "confirm two-factor enrollment",
                 "parameters": [
                     {
-                        "description": "token refresh request",
+                        "description": "two-factor confirm request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.Refresh"
+                            "$ref": "#/definitions/request.TwoFactorConfirm"
                         }
                     }
                 ],
@@ -174,7 +265,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.Token"
+                                            "$ref": "#/definitions/dto.RecoveryCodes"
                                         }
                                     }
                                 }
@@ -184,119 +275,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/categories": {
-            "get": {
+        "/v1/auth/2fa/disable": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "produces": [
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "auth"
                 ],
-                "summary": "categories list",
+                "summary": "disable two-factor authentication",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "pagination[page]",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "count",
-                        "name": "pagination[count]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "created_at",
-                        "name": "sorts[created_at]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "updated_at",
-                        "name": "sorts[updated_at]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "name",
-                        "name": "sorts[name]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "slug",
-                        "name": "sorts[slug]",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "name",
-                        "name": "filters[name]",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "slug",
-                        "name": "filters[slug]",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "description",
-                        "name": "filters[description]",
-                        "in": "query"
+                        "description": "two-factor code request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorCode"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.list"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/model.Category"
-                                            }
-                                        },
-                                        "pagination": {
-                                            "$ref": "#/definitions/dto.Pagination"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
-            },
+            }
+        },
+        "/v1/auth/2fa/enroll": {
             "post": {
                 "security": [
                     {
@@ -310,23 +321,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "auth"
                 ],
-                "summary": "create category",
+                "summary": "start two-factor enrollment",
                 "parameters": [
                     {
-                        "description": "category create request",
+                        "description": "two-factor enroll request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CategoryCreate"
+                            "$ref": "#/definitions/request.TwoFactorEnroll"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -336,7 +347,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Category"
+                                            "$ref": "#/definitions/dto.TwoFactorEnrollment"
                                         }
                                     }
                                 }
@@ -346,27 +357,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/categories/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/v1/auth/challenge": {
+            "post": {
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "auth"
                 ],
-                "summary": "get category by id",
+                "summary": "verify login challenge with two-factor code",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "category id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "login challenge request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginChallenge"
+                        }
                     }
                 ],
                 "responses": {
@@ -381,7 +392,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Category"
+                                            "$ref": "#/definitions/dto.Token"
                                         }
                                     }
                                 }
@@ -389,7 +400,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/auth/devices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "get auth user devices",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.Device"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/devices/{id}": {
             "delete": {
                 "security": [
                     {
@@ -397,13 +449,13 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "categories"
+                    "auth"
                 ],
-                "summary": "delete category by id",
+                "summary": "revoke auth user device",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "category id",
+                        "description": "device id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -414,13 +466,10 @@ const docTemplate = `{
                         "description": "No Content"
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
+            }
+        },
+        "/v1/auth/google": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -428,24 +477,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "auth"
                 ],
-                "summary": "update profile",
+                "summary": "google login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "category id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "category update request",
+                        "description": "google login request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CategoryUpdate"
+                            "$ref": "#/definitions/request.GoogleLogin"
                         }
                     }
                 ],
@@ -461,115 +503,25 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Category"
+                                            "$ref": "#/definitions/dto.Token"
                                         }
                                     }
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/v1/permissions": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "permissions"
-                ],
-                "summary": "permissions list",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "pagination[page]",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "count",
-                        "name": "pagination[count]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "created_at",
-                        "name": "sorts[created_at]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "updated_at",
-                        "name": "sorts[updated_at]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "name",
-                        "name": "sorts[name]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "slug",
-                        "name": "sorts[slug]",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "name",
-                        "name": "filters[name]",
-                        "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "slug",
-                        "name": "filters[slug]",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/response.list"
+                                    "$ref": "#/definitions/response.success"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/model.Permission"
-                                            }
-                                        },
-                                        "pagination": {
-                                            "$ref": "#/definitions/dto.Pagination"
+                                            "$ref": "#/definitions/dto.Challenge"
                                         }
                                     }
                                 }
@@ -579,27 +531,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/permissions/{id}": {
-            "get": {
+        "/v1/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "logout from current device",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/auth/logout-all": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "logout from all devices",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/auth/oidc/{provider}": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "permissions"
+                    "auth"
                 ],
-                "summary": "get permission by id",
+                "summary": "oidc login",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "permission id",
-                        "name": "id",
+                        "description": "provider name",
+                        "name": "provider",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "oidc login request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.OIDCLogin"
+                        }
                     }
                 ],
                 "responses": {
@@ -614,7 +609,25 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Permission"
+                                            "$ref": "#/definitions/dto.Token"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Challenge"
                                         }
                                     }
                                 }
@@ -624,104 +637,94 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/questions": {
-            "get": {
+        "/v1/auth/password": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "produces": [
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "auth"
                 ],
-                "summary": "questions list",
+                "summary": "change auth user password",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "pagination[page]",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "count",
-                        "name": "pagination[count]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "created_at",
-                        "name": "sorts[created_at]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "updated_at",
-                        "name": "sorts[updated_at]",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "text",
-                        "name": "filters[text]",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "junior",
-                            "middle",
-                            "senior"
-                        ],
-                        "type": "string",
-                        "description": "grade",
-                        "name": "filters[grade]",
-                        "in": "query"
+                        "description": "password change request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PasswordChange"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/auth/password/forgot": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "request password reset",
+                "parameters": [
+                    {
+                        "description": "password forgot request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.list"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/model.Question"
-                                            }
-                                        },
-                                        "pagination": {
-                                            "$ref": "#/definitions/dto.Pagination"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/request.PasswordForgot"
                         }
                     }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
                 }
-            },
+            }
+        },
+        "/v1/auth/password/reset": {
             "post": {
-                "security": [
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "reset password",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "description": "password reset request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PasswordReset"
+                        }
                     }
                 ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/auth/refresh": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -729,23 +732,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "auth"
                 ],
-                "summary": "create question",
+                "summary": "refresh token",
                 "parameters": [
                     {
-                        "description": "question create request",
+                        "description": "token refresh request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.QuestionCreate"
+                            "$ref": "#/definitions/request.Refresh"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -755,7 +758,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Question"
+                                            "$ref": "#/definitions/dto.Token"
                                         }
                                     }
                                 }
@@ -765,32 +768,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/questions/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/v1/auth/register": {
+            "post": {
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "auth"
                 ],
-                "summary": "get question by id",
+                "summary": "self registration",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "question id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "register request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.Register"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -800,7 +803,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Question"
+                                            "$ref": "#/definitions/model.User"
                                         }
                                     }
                                 }
@@ -808,24 +811,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            }
+        },
+        "/v1/auth/verify": {
+            "post": {
+                "consumes": [
+                    "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "auth"
                 ],
-                "summary": "delete question by id",
+                "summary": "verify email",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "question id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "verify email request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VerifyEmail"
+                        }
                     }
                 ],
                 "responses": {
@@ -833,64 +838,27 @@ const docTemplate = `{
                         "description": "No Content"
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/v1/auth/verify/resend": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "questions"
-                ],
-                "summary": "update profile",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "question id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "question update request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.QuestionUpdate"
-                        }
-                    }
+                    "auth"
                 ],
+                "summary": "resend verification email",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.success"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.Question"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/v1/roles": {
+        "/v1/categories": {
             "get": {
                 "security": [
                     {
@@ -901,9 +869,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "categories"
                 ],
-                "summary": "roles list",
+                "summary": "categories list",
                 "parameters": [
                     {
                         "type": "integer",
@@ -968,6 +936,24 @@ const docTemplate = `{
                         "description": "slug",
                         "name": "filters[slug]",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "description",
+                        "name": "filters[description]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated category ids",
+                        "name": "filters[ids]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only categories the user holds this permission for",
+                        "name": "filters[permission]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -984,7 +970,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/model.Role"
+                                                "$ref": "#/definitions/model.Category"
                                             }
                                         },
                                         "pagination": {
@@ -1010,17 +996,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "categories"
                 ],
-                "summary": "create role",
+                "summary": "create category",
                 "parameters": [
                     {
-                        "description": "role create request",
+                        "description": "category create request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.RoleCreate"
+                            "$ref": "#/definitions/request.CategoryCreate"
                         }
                     }
                 ],
@@ -1036,7 +1022,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Role"
+                                            "$ref": "#/definitions/model.Category"
                                         }
                                     }
                                 }
@@ -1046,7 +1032,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/roles/{id}": {
+        "/v1/categories/{id}": {
             "get": {
                 "security": [
                     {
@@ -1057,13 +1043,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "categories"
                 ],
-                "summary": "get role by id",
+                "summary": "get category by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "role id",
+                        "description": "category id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1081,7 +1067,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Role"
+                                            "$ref": "#/definitions/model.Category"
                                         }
                                     }
                                 }
@@ -1097,13 +1083,13 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "roles"
+                    "categories"
                 ],
-                "summary": "delete role by id",
+                "summary": "delete category by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "role id",
+                        "description": "category id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1128,24 +1114,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "categories"
                 ],
-                "summary": "update role by id",
+                "summary": "update profile",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "role id",
+                        "description": "category id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "role update request",
+                        "description": "category update request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.RoleUpdate"
+                            "$ref": "#/definitions/request.CategoryUpdate"
                         }
                     }
                 ],
@@ -1161,7 +1147,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Role"
+                                            "$ref": "#/definitions/model.Category"
                                         }
                                     }
                                 }
@@ -1171,31 +1157,97 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/roles/{id}/permissions/{permissionID}": {
-            "post": {
+        "/v1/lockouts": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "roles"
+                    "lockouts"
                 ],
-                "summary": "add permission",
+                "summary": "lockouts list",
                 "parameters": [
                     {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "pagination[page]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count",
+                        "name": "pagination[count]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
                         "type": "string",
-                        "description": "role id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "created_at",
+                        "name": "sorts[created_at]",
+                        "in": "query"
                     },
                     {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
                         "type": "string",
-                        "description": "permission id",
-                        "name": "permissionID",
-                        "in": "path",
-                        "required": true
+                        "description": "updated_at",
+                        "name": "sorts[updated_at]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "failures",
+                        "name": "sorts[failures]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "locked_until",
+                        "name": "sorts[locked_until]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "account",
+                            "ip"
+                        ],
+                        "type": "string",
+                        "description": "kind",
+                        "name": "filters[kind]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "email or ip",
+                        "name": "filters[key]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "1"
+                        ],
+                        "type": "string",
+                        "description": "only active locks",
+                        "name": "filters[is_locked]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1204,13 +1256,19 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/response.success"
+                                    "$ref": "#/definitions/response.list"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Role"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Lockout"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/dto.Pagination"
                                         }
                                     }
                                 }
@@ -1218,31 +1276,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/lockouts/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "roles"
+                    "lockouts"
                 ],
-                "summary": "remove permission",
+                "summary": "get lockout by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "role id",
+                        "description": "lockout id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "permission id",
-                        "name": "permissionID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1257,7 +1313,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Role"
+                                            "$ref": "#/definitions/model.Lockout"
                                         }
                                     }
                                 }
@@ -1265,9 +1321,34 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "lockouts"
+                ],
+                "summary": "clear lockout by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "lockout id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
         },
-        "/v1/sessions": {
+        "/v1/permissions": {
             "get": {
                 "security": [
                     {
@@ -1278,9 +1359,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sessions"
+                    "permissions"
                 ],
-                "summary": "get session list for auth user",
+                "summary": "permissions list",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1293,6 +1374,58 @@ const docTemplate = `{
                         "description": "count",
                         "name": "pagination[count]",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "created_at",
+                        "name": "sorts[created_at]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "updated_at",
+                        "name": "sorts[updated_at]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "name",
+                        "name": "sorts[name]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "slug",
+                        "name": "sorts[slug]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "filters[name]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "slug",
+                        "name": "filters[slug]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1309,7 +1442,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/model.Session"
+                                                "$ref": "#/definitions/model.Permission"
                                             }
                                         },
                                         "pagination": {
@@ -1321,8 +1454,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/permissions/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1332,23 +1467,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sessions"
+                    "permissions"
                 ],
-                "summary": "start test session",
+                "summary": "get permission by id",
                 "parameters": [
                     {
-                        "description": "session create request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SessionCreate"
-                        }
+                        "type": "string",
+                        "description": "permission id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1358,7 +1491,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Session"
+                                            "$ref": "#/definitions/model.Permission"
                                         }
                                     }
                                 }
@@ -1368,8 +1501,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/sessions/{id}/cancel": {
-            "post": {
+        "/v1/questions": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1379,16 +1512,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sessions"
+                    "questions"
                 ],
-                "summary": "finish the session without summary",
+                "summary": "questions list",
                 "parameters": [
                     {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "pagination[page]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count",
+                        "name": "pagination[count]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "created_at",
+                        "name": "sorts[created_at]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
                         "type": "string",
-                        "description": "session id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "updated_at",
+                        "name": "sorts[updated_at]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "text",
+                        "name": "filters[text]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "junior",
+                            "middle",
+                            "senior"
+                        ],
+                        "type": "string",
+                        "description": "grade",
+                        "name": "filters[grade]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated category ids",
+                        "name": "filters[category_ids]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only categories the user holds this permission for",
+                        "name": "filters[permission]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1397,13 +1584,19 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/response.success"
+                                    "$ref": "#/definitions/response.list"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Session"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Question"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/dto.Pagination"
                                         }
                                     }
                                 }
@@ -1411,34 +1604,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/sessions/{id}/summarize": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sessions"
+                    "questions"
                 ],
-                "summary": "finish the session with summary",
+                "summary": "create question",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "session id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "question create request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.QuestionCreate"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -1448,7 +1644,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Session"
+                                            "$ref": "#/definitions/model.Question"
                                         }
                                     }
                                 }
@@ -1458,7 +1654,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/sessions/{sessionID}/questions": {
+        "/v1/questions/{id}": {
             "get": {
                 "security": [
                     {
@@ -1469,14 +1665,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sessionQuestions"
+                    "questions"
                 ],
-                "summary": "get session questions",
+                "summary": "get question by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "session id",
-                        "name": "sessionID",
+                        "description": "question id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1493,10 +1689,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/model.SessionQuestion"
-                                            }
+                                            "$ref": "#/definitions/model.Question"
                                         }
                                     }
                                 }
@@ -1504,36 +1697,64 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/sessions/{sessionID}/questions/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "sessionQuestions"
+                    "questions"
                 ],
-                "summary": "get session question by id",
+                "summary": "delete question by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "session id",
-                        "name": "sessionID",
+                        "description": "question id",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "update profile",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "session question id",
+                        "description": "question id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "question update request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.QuestionUpdate"
+                        }
                     }
                 ],
                 "responses": {
@@ -1548,7 +1769,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.SessionQuestion"
+                                            "$ref": "#/definitions/model.Question"
                                         }
                                     }
                                 }
@@ -1556,46 +1777,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/v1/questions/{id}/stats": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sessionQuestions"
+                    "questions"
                 ],
-                "summary": "answer the question",
+                "summary": "get question answer statistics",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "session id",
-                        "name": "sessionID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "session question id",
+                        "description": "question id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "answer the question request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SessionQuestionUpdate"
-                        }
                     }
                 ],
                 "responses": {
@@ -1610,7 +1814,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.SessionQuestion"
+                                            "$ref": "#/definitions/dto.QuestionStats"
                                         }
                                     }
                                 }
@@ -1620,7 +1824,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users": {
+        "/v1/roles": {
             "get": {
                 "security": [
                     {
@@ -1631,9 +1835,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "roles"
                 ],
-                "summary": "users list",
+                "summary": "roles list",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1667,6 +1871,26 @@ const docTemplate = `{
                         "name": "sorts[updated_at]",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "name",
+                        "name": "sorts[name]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "slug",
+                        "name": "sorts[slug]",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "name",
@@ -1675,8 +1899,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "email",
-                        "name": "filters[email]",
+                        "description": "slug",
+                        "name": "filters[slug]",
                         "in": "query"
                     }
                 ],
@@ -1694,7 +1918,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/model.User"
+                                                "$ref": "#/definitions/model.Role"
                                             }
                                         },
                                         "pagination": {
@@ -1708,6 +1932,11 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1715,17 +1944,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "roles"
                 ],
-                "summary": "registration",
+                "summary": "create role",
                 "parameters": [
                     {
-                        "description": "user create request",
+                        "description": "role create request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UserCreate"
+                            "$ref": "#/definitions/request.RoleCreate"
                         }
                     }
                 ],
@@ -1741,7 +1970,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.User"
+                                            "$ref": "#/definitions/model.Role"
                                         }
                                     }
                                 }
@@ -1751,7 +1980,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/{id}": {
+        "/v1/roles/{id}": {
             "get": {
                 "security": [
                     {
@@ -1762,13 +1991,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "roles"
                 ],
-                "summary": "get user by id",
+                "summary": "get role by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user id",
+                        "description": "role id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1786,7 +2015,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.User"
+                                            "$ref": "#/definitions/model.Role"
                                         }
                                     }
                                 }
@@ -1802,13 +2031,13 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "users"
+                    "roles"
                 ],
-                "summary": "delete user by id",
+                "summary": "delete role by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user id",
+                        "description": "role id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1833,24 +2062,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "roles"
                 ],
-                "summary": "update profile",
+                "summary": "update role by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user id",
+                        "description": "role id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "user update request",
+                        "description": "role update request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UserUpdate"
+                            "$ref": "#/definitions/request.RoleUpdate"
                         }
                     }
                 ],
@@ -1866,7 +2095,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.User"
+                                            "$ref": "#/definitions/model.Role"
                                         }
                                     }
                                 }
@@ -1876,7 +2105,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/{id}/roles/{roleID}": {
+        "/v1/roles/{id}/parents/{parentID}": {
             "post": {
                 "security": [
                     {
@@ -1884,21 +2113,21 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "users"
+                    "roles"
                 ],
-                "summary": "add role",
+                "summary": "add parent role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user id",
+                        "description": "role id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "role id",
-                        "name": "roleID",
+                        "description": "parent role id",
+                        "name": "parentID",
                         "in": "path",
                         "required": true
                     }
@@ -1915,7 +2144,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.User"
+                                            "$ref": "#/definitions/model.Role"
                                         }
                                     }
                                 }
@@ -1931,21 +2160,21 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "users"
+                    "roles"
                 ],
-                "summary": "remove role",
+                "summary": "remove parent role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user id",
+                        "description": "role id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "role id",
-                        "name": "roleID",
+                        "description": "parent role id",
+                        "name": "parentID",
                         "in": "path",
                         "required": true
                     }
@@ -1962,7 +2191,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.User"
+                                            "$ref": "#/definitions/model.Role"
                                         }
                                     }
                                 }
@@ -1971,109 +2200,1708 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "def.GradeName": {
-            "type": "string",
-            "enum": [
-                "junior",
-                "middle",
-                "senior"
-            ],
-            "x-enum-varnames": [
-                "GradeJunior",
-                "GradeMiddle",
-                "GradeSenior"
-            ]
-        },
-        "dto.Pagination": {
-            "type": "object",
-            "properties": {
-                "current_page": {
-                    "type": "integer"
-                },
-                "page_count": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
-                }
-            }
         },
-        "dto.Token": {
-            "type": "object",
-            "properties": {
-                "access_token": {
-                    "type": "string"
-                },
-                "refresh_token": {
-                    "type": "string"
+        "/v1/roles/{id}/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "role permissions expanded through parent roles and wildcards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "role id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RolePermissions"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
                 }
             }
         },
-        "model.Category": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "slug": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+        "/v1/roles/{id}/permissions/{permissionID}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "add permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "role id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "permission id",
+                        "name": "permissionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Role"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
                 }
-            }
-        },
-        "model.Permission": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "slug": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "remove permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "role id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "permission id",
+                        "name": "permissionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Role"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
                 }
             }
         },
-        "model.Question": {
-            "type": "object",
-            "properties": {
-                "category_id": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "grade": {
-                    "$ref": "#/definitions/def.GradeName"
-                },
-                "id": {
-                    "type": "string"
-                },
+        "/v1/session-templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessionTemplates"
+                ],
+                "summary": "session templates list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "pagination[page]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count",
+                        "name": "pagination[count]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "created_at",
+                        "name": "sorts[created_at]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "updated_at",
+                        "name": "sorts[updated_at]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "name",
+                        "name": "sorts[name]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "filters[name]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "description",
+                        "name": "filters[description]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.list"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.SessionTemplate"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/dto.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessionTemplates"
+                ],
+                "summary": "create session template",
+                "parameters": [
+                    {
+                        "description": "session template create request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SessionTemplateCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SessionTemplate"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/session-templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessionTemplates"
+                ],
+                "summary": "get session template by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session template id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SessionTemplate"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "sessionTemplates"
+                ],
+                "summary": "delete session template by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session template id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessionTemplates"
+                ],
+                "summary": "update session template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session template id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "session template update request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SessionTemplateUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SessionTemplate"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "get session list for auth user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "pagination[page]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count",
+                        "name": "pagination[count]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.list"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Session"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/dto.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "start test session",
+                "parameters": [
+                    {
+                        "description": "session create request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SessionCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Session"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sessions/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "finish the session without summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Session"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sessions/{id}/next": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "get next question of adaptive session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SessionQuestion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sessions/{id}/summarize": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "finish the session with summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Session"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sessions/{sessionID}/questions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessionQuestions"
+                ],
+                "summary": "get session questions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session id",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.SessionQuestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sessions/{sessionID}/questions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessionQuestions"
+                ],
+                "summary": "get session question by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session id",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "session question id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SessionQuestion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessionQuestions"
+                ],
+                "summary": "answer the question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session id",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "session question id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "answer the question request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SessionQuestionUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SessionQuestion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "users list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "pagination[page]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "count",
+                        "name": "pagination[count]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "created_at",
+                        "name": "sorts[created_at]",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "updated_at",
+                        "name": "sorts[updated_at]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "filters[name]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "email",
+                        "name": "filters[email]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.list"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.User"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/dto.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "registration",
+                "parameters": [
+                    {
+                        "description": "user create request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UserCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get user by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "delete user by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "update profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "user update request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/roles/{roleID}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "add role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "role id",
+                        "name": "roleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "grant the role only for this category",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "remove role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "role id",
+                        "name": "roleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "remove the grant scoped to this category",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.success"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "def.AuditAction": {
+            "type": "string",
+            "enum": [
+                "role.create",
+                "role.update",
+                "role.delete",
+                "role.add_permission",
+                "role.remove_permission",
+                "role.add_parent",
+                "role.remove_parent",
+                "user.create",
+                "user.delete",
+                "user.add_role",
+                "user.remove_role",
+                "question.create",
+                "question.update",
+                "question.delete"
+            ],
+            "x-enum-varnames": [
+                "AuditRoleCreate",
+                "AuditRoleUpdate",
+                "AuditRoleDelete",
+                "AuditRoleAddPermission",
+                "AuditRoleRemovePermission",
+                "AuditRoleAddParent",
+                "AuditRoleRemoveParent",
+                "AuditUserCreate",
+                "AuditUserDelete",
+                "AuditUserAddRole",
+                "AuditUserRemoveRole",
+                "AuditQuestionCreate",
+                "AuditQuestionUpdate",
+                "AuditQuestionDelete"
+            ]
+        },
+        "def.AuditTarget": {
+            "type": "string",
+            "enum": [
+                "role",
+                "user",
+                "question"
+            ],
+            "x-enum-varnames": [
+                "AuditTargetRole",
+                "AuditTargetUser",
+                "AuditTargetQuestion"
+            ]
+        },
+        "def.EvaluationStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "done",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "EvaluationPending",
+                "EvaluationRunning",
+                "EvaluationDone",
+                "EvaluationFailed"
+            ]
+        },
+        "def.GradeName": {
+            "type": "string",
+            "enum": [
+                "junior",
+                "middle",
+                "senior"
+            ],
+            "x-enum-varnames": [
+                "GradeJunior",
+                "GradeMiddle",
+                "GradeSenior"
+            ]
+        },
+        "def.LockoutKind": {
+            "type": "string",
+            "enum": [
+                "account",
+                "ip",
+                "password_reset_account",
+                "password_reset_ip"
+            ],
+            "x-enum-varnames": [
+                "LockoutAccount",
+                "LockoutIP",
+                "LockoutPasswordResetAccount",
+                "LockoutPasswordResetIP"
+            ]
+        },
+        "def.QuestionType": {
+            "type": "string",
+            "enum": [
+                "text",
+                "single_choice",
+                "multiple_choice",
+                "code"
+            ],
+            "x-enum-varnames": [
+                "QuestionText",
+                "QuestionSingleChoice",
+                "QuestionMultipleChoice",
+                "QuestionCode"
+            ]
+        },
+        "def.SessionMode": {
+            "type": "string",
+            "enum": [
+                "fixed",
+                "adaptive"
+            ],
+            "x-enum-varnames": [
+                "SessionFixed",
+                "SessionAdaptive"
+            ]
+        },
+        "def.VerdictName": {
+            "type": "string",
+            "enum": [
+                "below",
+                "meets",
+                "above"
+            ],
+            "x-enum-varnames": [
+                "VerdictBelow",
+                "VerdictMeets",
+                "VerdictAbove"
+            ]
+        },
+        "dto.Challenge": {
+            "type": "object",
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Device": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "family_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "is_current": {
+                    "type": "boolean"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Me": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verification_pending": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "role_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scoped_permissions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "scoped_roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ScopedRole"
+                    }
+                },
+                "totp_enabled": {
+                    "type": "boolean"
+                },
+                "two_factor_required": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Pagination": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "page_count": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.QuestionStats": {
+            "type": "object",
+            "properties": {
+                "answered": {
+                    "type": "integer"
+                },
+                "asked": {
+                    "type": "integer"
+                },
+                "asked_before_edit": {
+                    "type": "integer"
+                },
+                "average_score": {
+                    "type": "integer"
+                },
+                "evaluated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.RolePermissions": {
+            "type": "object",
+            "properties": {
+                "inherited_role_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "slugs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.Token": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TwoFactorEnrollment": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "model.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/def.AuditAction"
+                },
+                "actor_email": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.AuditChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "$ref": "#/definitions/def.AuditTarget"
+                }
+            }
+        },
+        "model.Category": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Lockout": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/def.LockoutKind"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Permission": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Question": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "code": {
+                    "$ref": "#/definitions/model.QuestionCode"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "grade": {
+                    "$ref": "#/definitions/def.GradeName"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.QuestionOption"
+                    }
+                },
+                "rubric": {
+                    "$ref": "#/definitions/model.QuestionRubric"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/def.QuestionType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.QuestionCode": {
+            "type": "object",
+            "properties": {
+                "stub": {
+                    "type": "string"
+                },
+                "tests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.QuestionTest"
+                    }
+                }
+            }
+        },
+        "model.QuestionCriterion": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.QuestionOption": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "is_correct": {
+                    "type": "boolean"
+                },
                 "text": {
                     "type": "string"
+                }
+            }
+        },
+        "model.QuestionRubric": {
+            "type": "object",
+            "properties": {
+                "criteria": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.QuestionCriterion"
+                    }
+                },
+                "key_points": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reference_answer": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.QuestionTest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
                 }
             }
@@ -2090,12 +3918,21 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "parent_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "permission_ids": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "require_totp": {
+                    "type": "boolean"
+                },
                 "slug": {
                     "type": "string"
                 },
@@ -2104,6 +3941,17 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ScopedRole": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string"
+                }
+            }
+        },
         "model.Session": {
             "type": "object",
             "properties": {
@@ -2113,6 +3961,12 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "estimated_grade": {
+                    "$ref": "#/definitions/def.GradeName"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
                 "finished_at": {
                     "type": "string"
                 },
@@ -2122,40 +3976,223 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "mode": {
+                    "$ref": "#/definitions/def.SessionMode"
+                },
+                "question_time_limit_second": {
+                    "type": "integer"
+                },
+                "report": {
+                    "$ref": "#/definitions/model.SessionReport"
+                },
                 "summary": {
                     "type": "string"
                 },
+                "summary_status": {
+                    "$ref": "#/definitions/def.EvaluationStatus"
+                },
+                "template_id": {
+                    "type": "string"
+                },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
+        "model.SessionBucket": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "grade": {
+                    "$ref": "#/definitions/def.GradeName"
+                }
+            }
+        },
+        "model.SessionOption": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
         "model.SessionQuestion": {
             "type": "object",
             "properties": {
                 "answer": {
                     "type": "string"
                 },
+                "answer_option_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category_id": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "evaluation_status": {
+                    "$ref": "#/definitions/def.EvaluationStatus"
+                },
+                "grade": {
+                    "$ref": "#/definitions/def.GradeName"
+                },
                 "id": {
                     "type": "string"
                 },
+                "opened_at": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SessionOption"
+                    }
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
                 "session_id": {
                     "type": "string"
                 },
+                "source_updated_at": {
+                    "type": "string"
+                },
+                "strengths": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stub": {
+                    "type": "string"
+                },
                 "summary": {
                     "type": "string"
                 },
+                "test_results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TestResult"
+                    }
+                },
                 "text": {
                     "type": "string"
                 },
+                "type": {
+                    "$ref": "#/definitions/def.QuestionType"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weaknesses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.SessionReport": {
+            "type": "object",
+            "properties": {
+                "answered": {
+                    "type": "integer"
+                },
+                "recommended_grade": {
+                    "$ref": "#/definitions/def.GradeName"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SessionTopic"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "verdict": {
+                    "$ref": "#/definitions/def.VerdictName"
+                }
+            }
+        },
+        "model.SessionTemplate": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SessionBucket"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
+        "model.SessionTopic": {
+            "type": "object",
+            "properties": {
+                "answered": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TestResult": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "output": {
+                    "type": "string"
+                },
+                "passed": {
+                    "type": "boolean"
+                }
+            }
+        },
         "model.User": {
             "type": "object",
             "properties": {
@@ -2168,6 +4205,9 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "email_verification_pending": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -2180,75 +4220,174 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "scoped_roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ScopedRole"
+                    }
+                },
+                "totp_enabled": {
+                    "type": "boolean"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "request.CategoryCreate": {
+        "request.CategoryCreate": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 5
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.CategoryUpdate": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 5
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.GoogleLogin": {
+            "type": "object",
+            "required": [
+                "token_id"
+            ],
+            "properties": {
+                "token_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.Login": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 8
+                }
+            }
+        },
+        "request.LoginChallenge": {
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "request.OIDCLogin": {
+            "type": "object",
+            "required": [
+                "id_token"
+            ],
+            "properties": {
+                "id_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PasswordChange": {
             "type": "object",
             "required": [
-                "description",
-                "name"
+                "current_password",
+                "new_password"
             ],
             "properties": {
-                "description": {
+                "current_password": {
                     "type": "string",
-                    "maxLength": 500,
-                    "minLength": 5
+                    "maxLength": 50
                 },
-                "name": {
+                "new_password": {
                     "type": "string",
                     "maxLength": 50,
-                    "minLength": 1
+                    "minLength": 8
                 }
             }
         },
-        "request.CategoryUpdate": {
+        "request.PasswordForgot": {
             "type": "object",
             "required": [
-                "description",
-                "name"
+                "email"
             ],
             "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 500,
-                    "minLength": 5
-                },
-                "name": {
+                "email": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 1
+                    "maxLength": 50
                 }
             }
         },
-        "request.GoogleLogin": {
+        "request.PasswordReset": {
             "type": "object",
             "required": [
-                "token_id"
+                "password",
+                "token"
             ],
             "properties": {
-                "token_id": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 8
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "request.Login": {
+        "request.QuestionCode": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "stub": {
                     "type": "string",
-                    "maxLength": 50
+                    "maxLength": 5000
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 8
+                "tests": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/request.QuestionTest"
+                    }
                 }
             }
         },
@@ -2263,6 +4402,9 @@ const docTemplate = `{
                 "category_id": {
                     "type": "string"
                 },
+                "code": {
+                    "$ref": "#/definitions/request.QuestionCode"
+                },
                 "grade": {
                     "type": "string",
                     "enum": [
@@ -2271,10 +4413,111 @@ const docTemplate = `{
                         "senior"
                     ]
                 },
+                "options": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/request.QuestionOption"
+                    }
+                },
+                "rubric": {
+                    "$ref": "#/definitions/request.QuestionRubric"
+                },
                 "text": {
                     "type": "string",
                     "maxLength": 200,
                     "minLength": 3
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "single_choice",
+                        "multiple_choice",
+                        "code"
+                    ]
+                }
+            }
+        },
+        "request.QuestionCriterion": {
+            "type": "object",
+            "required": [
+                "name",
+                "weight"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 3
+                },
+                "weight": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                }
+            }
+        },
+        "request.QuestionOption": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "is_correct": {
+                    "type": "boolean"
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.QuestionRubric": {
+            "type": "object",
+            "required": [
+                "key_points"
+            ],
+            "properties": {
+                "criteria": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/request.QuestionCriterion"
+                    }
+                },
+                "key_points": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reference_answer": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "request.QuestionTest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
                 }
             }
         },
@@ -2285,6 +4528,9 @@ const docTemplate = `{
                 "text"
             ],
             "properties": {
+                "code": {
+                    "$ref": "#/definitions/request.QuestionCode"
+                },
                 "grade": {
                     "type": "string",
                     "enum": [
@@ -2293,10 +4539,29 @@ const docTemplate = `{
                         "senior"
                     ]
                 },
+                "options": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/request.QuestionOption"
+                    }
+                },
+                "rubric": {
+                    "$ref": "#/definitions/request.QuestionRubric"
+                },
                 "text": {
                     "type": "string",
                     "maxLength": 200,
                     "minLength": 3
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "single_choice",
+                        "multiple_choice",
+                        "code"
+                    ]
                 }
             }
         },
@@ -2315,6 +4580,29 @@ const docTemplate = `{
                 }
             }
         },
+        "request.Register": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 8
+                }
+            }
+        },
         "request.RoleCreate": {
             "type": "object",
             "required": [
@@ -2325,6 +4613,9 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 50,
                     "minLength": 5
+                },
+                "require_totp": {
+                    "type": "boolean"
                 }
             }
         },
@@ -2338,15 +4629,40 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 50,
                     "minLength": 5
+                },
+                "require_totp": {
+                    "type": "boolean"
                 }
             }
         },
-        "request.SessionCreate": {
+        "request.SessionBucket": {
             "type": "object",
             "required": [
                 "category_id",
+                "count",
                 "grade"
             ],
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "grade": {
+                    "type": "string",
+                    "enum": [
+                        "junior",
+                        "middle",
+                        "senior"
+                    ]
+                }
+            }
+        },
+        "request.SessionCreate": {
+            "type": "object",
             "properties": {
                 "category_id": {
                     "type": "string"
@@ -2358,19 +4674,137 @@ const docTemplate = `{
                         "middle",
                         "senior"
                     ]
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "fixed",
+                        "adaptive"
+                    ]
+                },
+                "question_time_limit_second": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 10
+                },
+                "template_id": {
+                    "type": "string"
+                },
+                "time_limit_minute": {
+                    "type": "integer",
+                    "maximum": 480,
+                    "minimum": 1
                 }
             }
         },
         "request.SessionQuestionUpdate": {
             "type": "object",
-            "required": [
-                "answer"
-            ],
             "properties": {
                 "answer": {
                     "type": "string",
                     "maxLength": 500,
                     "minLength": 1
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 20000
+                },
+                "option_ids": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.SessionTemplateCreate": {
+            "type": "object",
+            "required": [
+                "buckets",
+                "name"
+            ],
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.SessionBucket"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "request.SessionTemplateUpdate": {
+            "type": "object",
+            "required": [
+                "buckets",
+                "name"
+            ],
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.SessionBucket"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "request.TwoFactorCode": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "request.TwoFactorConfirm": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "request.TwoFactorEnroll": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 50
                 }
             }
         },
@@ -2409,6 +4843,17 @@ const docTemplate = `{
                 }
             }
         },
+        "request.VerifyEmail": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "response.list": {
             "type": "object",
             "properties": {
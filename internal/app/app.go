@@ -1,21 +1,36 @@
 package app
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
+	"net/http"
 	"tech_check/internal/config"
+	"tech_check/internal/def"
+	"tech_check/internal/evaluator"
+	"tech_check/internal/mailer"
+	"tech_check/internal/model"
+	"tech_check/internal/oidc"
 	"tech_check/internal/repo/mongo_repo"
+	"tech_check/internal/runner"
+	"tech_check/internal/signer"
 	"tech_check/internal/srvc"
+	"tech_check/internal/totp"
 	"tech_check/internal/util"
+	"tech_check/internal/worker"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type (
 	App struct {
-		Cfg   *config.Config
-		Lg    *slog.Logger
-		Mng   *mongo.Database
-		Srvcs *srvcs
+		Cfg       *config.Config
+		Lg        *slog.Logger
+		Mng       *mongo.Database
+		Srvcs     *srvcs
+		Workers   *worker.Pool
+		Scheduler *worker.Scheduler
 	}
 
 	repos struct {
@@ -26,7 +41,13 @@ type (
 		Category        *mongo_repo.Category
 		Question        *mongo_repo.Question
 		Session         *mongo_repo.Session
+		SessionTemplate *mongo_repo.SessionTemplate
 		SessionQuestion *mongo_repo.SessionQuestion
+		Job             *mongo_repo.Job
+		SecurityEvent   *mongo_repo.SecurityEvent
+		PasswordReset   *mongo_repo.PasswordReset
+		Lockout         *mongo_repo.Lockout
+		AuditLog        *mongo_repo.AuditLog
 	}
 
 	srvcs struct {
@@ -38,7 +59,16 @@ type (
 		Category        *srvc.Category
 		Question        *srvc.Question
 		Session         *srvc.Session
+		SessionTemplate *srvc.SessionTemplate
 		SessionQuestion *srvc.SessionQuestion
+		Job             *srvc.Job
+		SecurityEvent   *srvc.SecurityEvent
+		Mail            *srvc.Mail
+		PasswordReset   *srvc.PasswordReset
+		Registration    *srvc.Registration
+		TwoFactor       *srvc.TwoFactor
+		Lockout         *srvc.Lockout
+		AuditLog        *srvc.AuditLog
 	}
 )
 
@@ -48,13 +78,24 @@ func MustNew() *App {
 	mng := mustSetupMongo(cfg)
 
 	repos := setupRepositories(mng)
-	srvcs := setupServices(cfg, repos)
+	evaluator := mustSetupEvaluator(cfg)
+	codeRunner := setupCodeRunner(cfg)
+	tokenSigner := mustSetupTokenSigner(cfg)
+	oidcVerifier := setupOIDCVerifier(cfg)
+	mailRenderer := mailer.MustNewTemplates()
+	mailer := mustSetupMailer(cfg, lg)
+	srvcs := setupServices(cfg, lg, repos, evaluator, codeRunner, tokenSigner, oidcVerifier, mailRenderer, mailer)
+	mustSyncPermissions(lg, srvcs)
+	workers := setupWorkers(cfg, lg, srvcs)
+	scheduler := setupScheduler(cfg, lg, srvcs)
 
 	return &App{
-		Cfg:   cfg,
-		Lg:    lg,
-		Mng:   mng,
-		Srvcs: srvcs,
+		Cfg:       cfg,
+		Lg:        lg,
+		Mng:       mng,
+		Srvcs:     srvcs,
+		Workers:   workers,
+		Scheduler: scheduler,
 	}
 }
 
@@ -66,7 +107,13 @@ func setupRepositories(mng *mongo.Database) *repos {
 	category := mongo_repo.NewCategory(mng)
 	question := mongo_repo.NewQuestion(mng)
 	session := mongo_repo.NewSession(mng)
+	sessionTemplate := mongo_repo.NewSessionTemplate(mng)
 	sessionQuestion := mongo_repo.NewSessionQuestion(mng)
+	job := mongo_repo.NewJob(mng)
+	securityEvent := mongo_repo.NewSecurityEvent(mng)
+	passwordReset := mongo_repo.NewPasswordReset(mng)
+	lockout := mongo_repo.NewLockout(mng)
+	auditLog := mongo_repo.NewAuditLog(mng)
 
 	return &repos{
 		User:            user,
@@ -76,34 +123,159 @@ func setupRepositories(mng *mongo.Database) *repos {
 		Category:        category,
 		Question:        question,
 		Session:         session,
+		SessionTemplate: sessionTemplate,
 		SessionQuestion: sessionQuestion,
+		Job:             job,
+		SecurityEvent:   securityEvent,
+		PasswordReset:   passwordReset,
+		Lockout:         lockout,
+		AuditLog:        auditLog,
 	}
 }
 
-func setupServices(cfg *config.Config, repos *repos) *srvcs {
+func setupServices(
+	cfg *config.Config,
+	lg *slog.Logger,
+	repos *repos,
+	evaluator srvc.Evaluator,
+	codeRunner srvc.CodeRunner,
+	tokenSigner srvc.TokenSigner,
+	oidcVerifier srvc.OIDCVerifier,
+	mailRenderer srvc.MailRenderer,
+	mailer srvc.Mailer,
+) *srvcs {
+	auditLog := srvc.NewAuditLog(repos.AuditLog, lg)
 	permission := srvc.NewPermission(repos.Permission)
-	role := srvc.NewRole(repos.Role, permission)
-	user := srvc.NewUser(repos.User, role)
-	refreshToken := srvc.NewRefreshToken(repos.RefreshToken)
-	auth := srvc.NewAuth(cfg.Google.ClientID, cfg.JWT.Secret, user, refreshToken)
+	effectivePermission := srvc.NewEffectivePermission(cfg.Permission.CacheTTLSecond, repos.Role, repos.Permission)
+	role := srvc.NewRole(repos.Role, permission, effectivePermission, auditLog)
 	category := srvc.NewCategory(repos.Category)
-	question := srvc.NewQuestion(repos.Question, category)
-	sessionQuestion := srvc.NewSessionQuestion(repos.SessionQuestion)
-	session := srvc.NewSession(repos.Session, category, question, sessionQuestion)
+	refreshToken := srvc.NewRefreshToken(repos.RefreshToken)
+	user := srvc.NewUser(repos.User, role, category, effectivePermission, refreshToken, auditLog)
+	securityEvent := srvc.NewSecurityEvent(repos.SecurityEvent)
+	job := srvc.NewJob(repos.Job)
+	mail := srvc.NewMail(job, mailRenderer, mailer)
+	passwordReset := srvc.NewPasswordReset(cfg.Password.ResetTTLMinute, repos.PasswordReset)
+	lockout := srvc.NewLockout(
+		cfg.Lockout.AccountThreshold,
+		cfg.Lockout.IPThreshold,
+		cfg.Lockout.BaseSecond,
+		cfg.Lockout.MaxMinute,
+		cfg.Lockout.ResetHour,
+		repos.Lockout,
+	)
+	twoFactor := srvc.NewTwoFactor(totp.New(cfg.TwoFactor.Issuer), user, lockout)
+	auth := srvc.NewAuth(
+		cfg.Google.ClientID,
+		cfg.Password.ResetURL,
+		tokenSigner,
+		oidcVerifier,
+		user,
+		refreshToken,
+		securityEvent,
+		mail,
+		job,
+		passwordReset,
+		twoFactor,
+		lockout,
+	)
+	registration := srvc.NewRegistration(
+		cfg.Registration.IsEnabled,
+		cfg.Registration.AllowedDomains,
+		cfg.Registration.VerifyURL,
+		cfg.Registration.VerifyTTLHour,
+		tokenSigner,
+		user,
+		mail,
+	)
+	sessionQuestion := srvc.NewSessionQuestion(repos.SessionQuestion, category, job, evaluator, codeRunner)
+	question := srvc.NewQuestion(repos.Question, category, sessionQuestion, auditLog)
+	sessionTemplate := srvc.NewSessionTemplate(repos.SessionTemplate, category)
+	session := srvc.NewSession(cfg.Session.TimeLimitMinute, repos.Session, category, question, sessionTemplate, sessionQuestion, job)
 
 	return &srvcs{
-		User:         user,
-		Role:         role,
-		Permission:   permission,
-		RefreshToken: refreshToken,
-		Auth:         auth,
-		Category:     category,
-		Question:     question,
-		Session:      session,
+		User:            user,
+		Role:            role,
+		Permission:      permission,
+		RefreshToken:    refreshToken,
+		Auth:            auth,
+		Category:        category,
+		Question:        question,
+		Session:         session,
+		SessionTemplate: sessionTemplate,
 		SessionQuestion: sessionQuestion,
+		Job:             job,
+		SecurityEvent:   securityEvent,
+		Mail:            mail,
+		PasswordReset:   passwordReset,
+		Registration:    registration,
+		TwoFactor:       twoFactor,
+		Lockout:         lockout,
+		AuditLog:        auditLog,
+	}
+}
+
+func mustSyncPermissions(lg *slog.Logger, srvcs *srvcs) {
+	sync, err := srvcs.Permission.Sync(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	if len(sync.Created) > 0 {
+		lg.Info("permissions created", slog.Any("slugs", sync.Created))
+	}
+	if len(sync.Orphaned) > 0 {
+		lg.Warn("permissions not registered in code", slog.Any("slugs", sync.Orphaned))
 	}
 }
 
+func setupWorkers(cfg *config.Config, lg *slog.Logger, srvcs *srvcs) *worker.Pool {
+	pool := worker.NewPool(cfg.WorkerPool.Count, srvcs.Job, lg)
+
+	pool.Register(def.JobEvaluateAnswer, worker.Handler{
+		Run: func(ctx context.Context, job *model.Job) error {
+			return srvcs.Session.EvaluateQuestion(ctx, job.Payload[def.JobKeySessionID], job.Payload[def.JobKeyQuestionID])
+		},
+		Dead: func(ctx context.Context, job *model.Job) error {
+			return srvcs.Session.FailQuestionEvaluation(ctx, job.Payload[def.JobKeySessionID], job.Payload[def.JobKeyQuestionID])
+		},
+	})
+
+	pool.Register(def.JobSummarizeSession, worker.Handler{
+		Run: func(ctx context.Context, job *model.Job) error {
+			return srvcs.Session.BuildReport(ctx, job.Payload[def.JobKeySessionID])
+		},
+		Dead: func(ctx context.Context, job *model.Job) error {
+			return srvcs.Session.FailReport(ctx, job.Payload[def.JobKeySessionID])
+		},
+	})
+
+	pool.Register(def.JobSendMail, worker.Handler{
+		Run: func(ctx context.Context, job *model.Job) error {
+			return srvcs.Mail.Deliver(ctx, job.Payload)
+		},
+	})
+
+	pool.Register(def.JobPasswordReset, worker.Handler{
+		Run: func(ctx context.Context, job *model.Job) error {
+			return srvcs.Auth.SendPasswordReset(ctx, job.Payload[def.JobKeyEmail])
+		},
+	})
+
+	return pool
+}
+
+func setupScheduler(cfg *config.Config, lg *slog.Logger, srvcs *srvcs) *worker.Scheduler {
+	scheduler := worker.NewScheduler(lg)
+
+	scheduler.Register(worker.Task{
+		Name:     "finish_expired_sessions",
+		Interval: time.Duration(cfg.Session.SweepIntervalSecond) * time.Second,
+		Run:      srvcs.Session.FinishExpired,
+	})
+
+	return scheduler
+}
+
 func mustSetupConfig() *config.Config {
 	cfg, err := config.New()
 	if err != nil {
@@ -119,6 +291,88 @@ func mustSetupLogger(cfg *config.Config) *slog.Logger {
 	return lg
 }
 
+func mustSetupEvaluator(cfg *config.Config) srvc.Evaluator {
+	switch cfg.Evaluator.Driver {
+	case "rubric":
+		return evaluator.NewRubric()
+	case "openai":
+		return evaluator.NewOpenAI(
+			cfg.OpenAI.URL,
+			cfg.OpenAI.APIKey,
+			cfg.OpenAI.Model,
+			time.Duration(cfg.OpenAI.TimeoutSecond)*time.Second,
+		)
+	default:
+		panic(fmt.Sprintf("app.mustSetupEvaluator: unknown evaluator driver %q", cfg.Evaluator.Driver))
+	}
+}
+
+func setupCodeRunner(cfg *config.Config) srvc.CodeRunner {
+	return runner.NewGo(
+		cfg.Runner.GoBin,
+		cfg.Runner.PrlimitBin,
+		time.Duration(cfg.Runner.TimeoutSecond)*time.Second,
+		cfg.Runner.MemoryLimitMB,
+		cfg.Runner.OutputLimitKB,
+		cfg.Runner.MaxProcesses,
+		cfg.Runner.UID,
+		cfg.Runner.GID,
+	)
+}
+
+func mustSetupTokenSigner(cfg *config.Config) srvc.TokenSigner {
+	tokenSigner, err := signer.NewJWT(cfg.JWT.Secret, cfg.JWT.Keys, cfg.JWT.SigningKID)
+	if err != nil {
+		panic(err)
+	}
+
+	return tokenSigner
+}
+
+func setupOIDCVerifier(cfg *config.Config) srvc.OIDCVerifier {
+	providers := make([]oidc.ProviderConfig, 0, len(cfg.OIDC.Providers))
+	for _, provider := range cfg.OIDC.Providers {
+		providers = append(providers, oidc.ProviderConfig{
+			Name:                 provider.Name,
+			Issuer:               provider.Issuer,
+			ClientID:             provider.ClientID,
+			EmailClaim:           provider.EmailClaim,
+			NameClaim:            provider.NameClaim,
+			PictureClaim:         provider.PictureClaim,
+			AllowUnverifiedEmail: provider.AllowUnverifiedEmail,
+		})
+	}
+
+	return oidc.NewRegistry(
+		&http.Client{Timeout: time.Duration(cfg.OIDC.TimeoutSecond) * time.Second},
+		time.Duration(cfg.OIDC.CacheTTLMinute)*time.Minute,
+		providers,
+	)
+}
+
+func mustSetupMailer(cfg *config.Config, lg *slog.Logger) srvc.Mailer {
+	switch cfg.Mailer.Driver {
+	case "file":
+		return mailer.NewFile(cfg.Mailer.Dir, cfg.Mailer.From, lg)
+	case "smtp":
+		smtpMailer, err := mailer.NewSMTP(
+			cfg.SMTP.Host,
+			cfg.SMTP.Port,
+			cfg.SMTP.Username,
+			cfg.SMTP.Password,
+			cfg.Mailer.From,
+			time.Duration(cfg.SMTP.TimeoutSecond)*time.Second,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		return smtpMailer
+	default:
+		panic(fmt.Sprintf("app.mustSetupMailer: unknown mailer driver %q", cfg.Mailer.Driver))
+	}
+}
+
 func mustSetupMongo(cfg *config.Config) *mongo.Database {
 	mng, err := util.NewMongo(cfg.Mongo.DB, cfg.Mongo.URL)
 	if err != nil {
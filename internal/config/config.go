@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -8,13 +9,25 @@ import (
 
 type (
 	Config struct {
-		IsDebug    bool `env:"IS_DEBUG" env-default:"0"`
-		HTTP       HTTP
-		Log        Log
-		Mongo      Mongo
-		JWT        JWT
-		WorkerPool WorkerPool
-		Google     Google
+		IsDebug      bool `env:"IS_DEBUG" env-default:"0"`
+		HTTP         HTTP
+		Log          Log
+		Mongo        Mongo
+		JWT          JWT
+		WorkerPool   WorkerPool
+		Google       Google
+		OIDC         OIDC
+		Evaluator    Evaluator
+		OpenAI       OpenAI
+		Session      Session
+		Runner       Runner
+		Mailer       Mailer
+		Password     Password
+		Registration Registration
+		TwoFactor    TwoFactor
+		Lockout      Lockout
+		Permission   Permission
+		SMTP         SMTP
 	}
 
 	HTTP struct {
@@ -32,7 +45,9 @@ type (
 	}
 
 	JWT struct {
-		Secret string `env:"JWT_SECRET" env-required:"true"`
+		Secret     string            `env:"JWT_SECRET"`
+		Keys       map[string]string `env:"JWT_KEYS"`
+		SigningKID string            `env:"JWT_SIGNING_KID"`
 	}
 
 	WorkerPool struct {
@@ -42,8 +57,117 @@ type (
 	Google struct {
 		ClientID string `env:"GOOGLE_CLIENT_ID" env-required:"true"`
 	}
+
+	OIDC struct {
+		Providers      OIDCProviders `env:"OIDC_PROVIDERS"`
+		CacheTTLMinute int           `env:"OIDC_CACHE_TTL_MINUTE" env-default:"60"`
+		TimeoutSecond  int           `env:"OIDC_TIMEOUT_SECOND" env-default:"10"`
+	}
+
+	OIDCProviders []OIDCProvider
+
+	OIDCProvider struct {
+		Name                 string `json:"name"`
+		Issuer               string `json:"issuer"`
+		ClientID             string `json:"client_id"`
+		EmailClaim           string `json:"email_claim"`
+		NameClaim            string `json:"name_claim"`
+		PictureClaim         string `json:"picture_claim"`
+		AllowUnverifiedEmail bool   `json:"allow_unverified_email"`
+	}
+
+	Evaluator struct {
+		Driver string `env:"EVALUATOR_DRIVER" env-default:"rubric"`
+	}
+
+	Session struct {
+		TimeLimitMinute     int `env:"SESSION_TIME_LIMIT_MINUTE" env-default:"60"`
+		SweepIntervalSecond int `env:"SESSION_SWEEP_INTERVAL_SECOND" env-default:"60"`
+	}
+
+	Runner struct {
+		GoBin         string `env:"RUNNER_GO_BIN" env-default:"go"`
+		PrlimitBin    string `env:"RUNNER_PRLIMIT_BIN" env-default:"prlimit"`
+		TimeoutSecond int    `env:"RUNNER_TIMEOUT_SECOND" env-default:"10"`
+		MemoryLimitMB int    `env:"RUNNER_MEMORY_LIMIT_MB" env-default:"256"`
+		OutputLimitKB int    `env:"RUNNER_OUTPUT_LIMIT_KB" env-default:"64"`
+		MaxProcesses  int    `env:"RUNNER_MAX_PROCESSES" env-default:"128"`
+		UID           int    `env:"RUNNER_UID" env-default:"65534"`
+		GID           int    `env:"RUNNER_GID" env-default:"65534"`
+	}
+
+	Registration struct {
+		IsEnabled      bool     `env:"REGISTRATION_ENABLED" env-default:"0"`
+		AllowedDomains []string `env:"REGISTRATION_ALLOWED_DOMAINS"`
+		VerifyURL      string   `env:"REGISTRATION_VERIFY_URL" env-default:"http://localhost:3000/verify"`
+		VerifyTTLHour  int      `env:"REGISTRATION_VERIFY_TTL_HOUR" env-default:"48"`
+	}
+
+	TwoFactor struct {
+		Issuer string `env:"TWO_FACTOR_ISSUER" env-default:"tech_check"`
+	}
+
+	Lockout struct {
+		AccountThreshold int `env:"LOCKOUT_ACCOUNT_THRESHOLD" env-default:"5"`
+		IPThreshold      int `env:"LOCKOUT_IP_THRESHOLD" env-default:"20"`
+		BaseSecond       int `env:"LOCKOUT_BASE_SECOND" env-default:"30"`
+		MaxMinute        int `env:"LOCKOUT_MAX_MINUTE" env-default:"60"`
+		ResetHour        int `env:"LOCKOUT_RESET_HOUR" env-default:"24"`
+	}
+
+	Permission struct {
+		CacheTTLSecond int `env:"PERMISSION_CACHE_TTL_SECOND" env-default:"30"`
+	}
+
+	Password struct {
+		ResetURL       string `env:"PASSWORD_RESET_URL" env-default:"http://localhost:3000/password/reset"`
+		ResetTTLMinute int    `env:"PASSWORD_RESET_TTL_MINUTE" env-default:"30"`
+	}
+
+	Mailer struct {
+		Driver string `env:"MAILER_DRIVER" env-default:"file"`
+		From   string `env:"MAILER_FROM" env-default:"tech_check <no-reply@tech-check.local>"`
+		Dir    string `env:"MAILER_DIR" env-default:"./tmp/mail"`
+	}
+
+	SMTP struct {
+		Host          string `env:"SMTP_HOST" env-default:"localhost"`
+		Port          int    `env:"SMTP_PORT" env-default:"587"`
+		Username      string `env:"SMTP_USERNAME"`
+		Password      string `env:"SMTP_PASSWORD"`
+		TimeoutSecond int    `env:"SMTP_TIMEOUT_SECOND" env-default:"10"`
+	}
+
+	OpenAI struct {
+		URL           string `env:"OPENAI_URL" env-default:"https://api.openai.com/v1"`
+		APIKey        string `env:"OPENAI_API_KEY"`
+		Model         string `env:"OPENAI_MODEL" env-default:"gpt-4o-mini"`
+		TimeoutSecond int    `env:"OPENAI_TIMEOUT_SECOND" env-default:"30"`
+	}
 )
 
+// SetValue parses OIDC_PROVIDERS, a json array of providers.
+func (p *OIDCProviders) SetValue(value string) error {
+	const op = "config.OIDCProviders.SetValue"
+
+	if value == "" {
+		return nil
+	}
+
+	err := json.Unmarshal([]byte(value), p)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, provider := range *p {
+		if provider.Name == "" || provider.Issuer == "" || provider.ClientID == "" {
+			return fmt.Errorf("%s: name, issuer and client_id are required", op)
+		}
+	}
+
+	return nil
+}
+
 func New() (*Config, error) {
 	const op = "config.New"
 
@@ -0,0 +1,36 @@
+package def
+
+type AuditAction string
+
+const (
+	AuditRoleCreate           AuditAction = "role.create"
+	AuditRoleUpdate           AuditAction = "role.update"
+	AuditRoleDelete           AuditAction = "role.delete"
+	AuditRoleAddPermission    AuditAction = "role.add_permission"
+	AuditRoleRemovePermission AuditAction = "role.remove_permission"
+	AuditRoleAddParent        AuditAction = "role.add_parent"
+	AuditRoleRemoveParent     AuditAction = "role.remove_parent"
+	AuditUserCreate           AuditAction = "user.create"
+	AuditUserDelete           AuditAction = "user.delete"
+	AuditUserAddRole          AuditAction = "user.add_role"
+	AuditUserRemoveRole       AuditAction = "user.remove_role"
+	AuditQuestionCreate       AuditAction = "question.create"
+	AuditQuestionUpdate       AuditAction = "question.update"
+	AuditQuestionDelete       AuditAction = "question.delete"
+)
+
+func (aa AuditAction) String() string {
+	return string(aa)
+}
+
+type AuditTarget string
+
+const (
+	AuditTargetRole     AuditTarget = "role"
+	AuditTargetUser     AuditTarget = "user"
+	AuditTargetQuestion AuditTarget = "question"
+)
+
+func (at AuditTarget) String() string {
+	return string(at)
+}
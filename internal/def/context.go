@@ -3,7 +3,9 @@ package def
 type ContextKey string
 
 const (
-	ContextAuthUser ContextKey = "auth_user"
+	ContextAuthUser      ContextKey = "auth_user"
+	ContextAuthClaims    ContextKey = "auth_claims"
+	ContextCategoryScope ContextKey = "category_scope"
 )
 
 func (ck ContextKey) String() string {
@@ -1,27 +1,98 @@
 package def
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
 
 var (
 	ErrNotFound             = errors.New("resource not found")
 	ErrAlreadyExists        = errors.New("resource already exists")
 	ErrInvalidCredentials   = errors.New("invalid credentials")
 	ErrInvalidBody          = errors.New("request body cannot be empty")
+	ErrBodyTooLarge         = errors.New("request body is too large")
 	ErrAuthMissing          = errors.New("authorization header is missing")
 	ErrInvalidAuthFormat    = errors.New("invalid authorization header format")
 	ErrInvalidSigningMethod = errors.New("invalid signing method")
+	ErrUnknownSigningKey    = errors.New("unknown signing key")
 	ErrInvalidClaimsType    = errors.New("invalid claims type")
 	ErrATokenExpired        = errors.New("access token expired")
+	ErrATokenRevoked        = errors.New("access token revoked")
 	ErrInvalidUserType      = errors.New("invalid user type")
 	ErrCannotLogin          = errors.New("user cannot login")
 	ErrTokensMismatch       = errors.New("refresh token and access token mismatch")
 	ErrRTokenExpired        = errors.New("refresh token expired")
 	ErrInvalidRToken        = errors.New("invalid refresh token")
+	ErrRTokenReused         = errors.New("refresh token already used")
 	ErrInvalidGoogleData    = errors.New("invalid google data")
+	ErrUnknownProvider      = errors.New("unknown identity provider")
+	ErrInvalidIDToken       = errors.New("invalid id token")
 	ErrInvalidGradeValue    = errors.New("invalid grade value")
 	ErrValidation           = errors.New("validation error")
 	ErrAccessDenied         = errors.New("access denied")
 	ErrUserHasActiveSession = errors.New("user has active session")
 	ErrQuestionNotEnough    = errors.New("questions not enough")
 	ErrSessionFinished      = errors.New("session finished")
+	ErrInvalidEvaluation    = errors.New("invalid evaluation response")
+	ErrUnknownJobType       = errors.New("unknown job type")
+	ErrEvaluationPending    = errors.New("evaluation in progress")
+	ErrSessionExpired       = errors.New("session time limit exceeded")
+	ErrQuestionExpired      = errors.New("question time limit exceeded")
+	ErrQuestionNotOpened    = errors.New("question must be opened before answering")
+	ErrInvalidSessionMode   = errors.New("invalid session mode")
+	ErrSessionNotAdaptive   = errors.New("session is not adaptive")
+	ErrSessionQuestionsOver = errors.New("no questions left in session")
+	ErrInvalidQuestionType  = errors.New("invalid question type")
+	ErrInvalidOptions       = errors.New("invalid question options")
+	ErrInvalidAnswer        = errors.New("answer does not match question type")
+	ErrUnknownOption        = errors.New("answer references unknown option")
+	ErrQuestionAnswered     = errors.New("question already answered")
+	ErrInvalidCode          = errors.New("invalid question code")
+	ErrUnknownMailTemplate  = errors.New("unknown mail template")
+	ErrInvalidResetToken    = errors.New("invalid or expired password reset token")
+	ErrRegistrationDisabled = errors.New("registration is disabled")
+	ErrEmailDomainDenied    = errors.New("email domain is not allowed")
+	ErrInvalidVerifyToken   = errors.New("invalid or expired verification token")
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+	ErrEmailNotVerified     = errors.New("email is not verified")
+	ErrTwoFactorRequired    = errors.New("role requires two-factor authentication")
+	ErrTwoFactorEnabled     = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotEnrolled = errors.New("two-factor authentication is not enrolled")
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	ErrInvalidChallenge     = errors.New("invalid or expired login challenge")
+	ErrTooManyAttempts      = errors.New("too many failed login attempts")
+	ErrRoleCycle            = errors.New("role inheritance cycle")
 )
+
+type QuestionNotEnoughError struct {
+	Category string
+	Grade    GradeName
+	Want     int
+	Got      int
+}
+
+func (e *QuestionNotEnoughError) Error() string {
+	return fmt.Sprintf("%s: %s %s needs %d, found %d", ErrQuestionNotEnough, e.Category, e.Grade, e.Want, e.Got)
+}
+
+func (e *QuestionNotEnoughError) Is(target error) bool {
+	return target == ErrQuestionNotEnough
+}
+
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("%s, retry in %d seconds", ErrTooManyAttempts, e.RetryAfterSeconds())
+}
+
+func (e *TooManyAttemptsError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+func (e *TooManyAttemptsError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
@@ -0,0 +1,18 @@
+package def
+
+type EvaluationStatus string
+
+const (
+	EvaluationPending EvaluationStatus = "pending"
+	EvaluationRunning EvaluationStatus = "running"
+	EvaluationDone    EvaluationStatus = "done"
+	EvaluationFailed  EvaluationStatus = "failed"
+)
+
+func (es EvaluationStatus) String() string {
+	return string(es)
+}
+
+func (es EvaluationStatus) IsFinal() bool {
+	return es == EvaluationDone || es == EvaluationFailed
+}
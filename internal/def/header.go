@@ -7,6 +7,9 @@ const (
 	HeaderContentType   HeaderKey = "Content-Type"
 	HeaderForwardedFor  HeaderKey = "X-Forwarded-For"
 	HeaderAuthorization HeaderKey = "Authorization"
+	HeaderUserAgent     HeaderKey = "User-Agent"
+	HeaderCacheControl  HeaderKey = "Cache-Control"
+	HeaderRetryAfter    HeaderKey = "Retry-After"
 )
 
 func (hk HeaderKey) String() string {
@@ -0,0 +1,36 @@
+package def
+
+type (
+	JobType   string
+	JobStatus string
+)
+
+const (
+	JobEvaluateAnswer   JobType = "evaluate_answer"
+	JobSummarizeSession JobType = "summarize_session"
+	JobSendMail         JobType = "send_mail"
+	JobPasswordReset    JobType = "password_reset"
+)
+
+const (
+	JobPending JobStatus = "pending"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobDead    JobStatus = "dead"
+)
+
+const (
+	JobKeySessionID    = "session_id"
+	JobKeyQuestionID   = "question_id"
+	JobKeyMailTo       = "mail_to"
+	JobKeyMailTemplate = "mail_template"
+	JobKeyEmail        = "email"
+)
+
+func (jt JobType) String() string {
+	return string(jt)
+}
+
+func (js JobStatus) String() string {
+	return string(js)
+}
@@ -0,0 +1,15 @@
+package def
+
+type LockoutKind string
+
+const (
+	LockoutAccount LockoutKind = "account"
+	LockoutIP      LockoutKind = "ip"
+
+	LockoutPasswordResetAccount LockoutKind = "password_reset_account"
+	LockoutPasswordResetIP      LockoutKind = "password_reset_ip"
+)
+
+func (lk LockoutKind) String() string {
+	return string(lk)
+}
@@ -0,0 +1,23 @@
+package def
+
+type MailTemplate string
+
+const (
+	MailNewDevice       MailTemplate = "new_device"
+	MailIPChanged       MailTemplate = "ip_changed"
+	MailPasswordChanged MailTemplate = "password_changed"
+	MailPasswordReset   MailTemplate = "password_reset"
+	MailVerifyEmail     MailTemplate = "email_verification"
+)
+
+const (
+	MailKeyName      = "name"
+	MailKeyIP        = "ip"
+	MailKeyUserAgent = "user_agent"
+	MailKeyTime      = "time"
+	MailKeyLink      = "link"
+)
+
+func (mt MailTemplate) String() string {
+	return string(mt)
+}
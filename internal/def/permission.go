@@ -0,0 +1,161 @@
+package def
+
+import "strings"
+
+type PermissionSlug string
+
+const (
+	PermissionAll PermissionSlug = "*"
+
+	PermissionAuditAll  PermissionSlug = "audit-*"
+	PermissionAuditRead PermissionSlug = "audit-read"
+
+	PermissionCategoryAll    PermissionSlug = "category-*"
+	PermissionCategoryRead   PermissionSlug = "category-read"
+	PermissionCategoryCreate PermissionSlug = "category-create"
+	PermissionCategoryEdit   PermissionSlug = "category-edit"
+	PermissionCategoryDelete PermissionSlug = "category-delete"
+
+	PermissionLockoutAll    PermissionSlug = "lockout-*"
+	PermissionLockoutRead   PermissionSlug = "lockout-read"
+	PermissionLockoutDelete PermissionSlug = "lockout-delete"
+
+	PermissionPermissionAll    PermissionSlug = "permission-*"
+	PermissionPermissionRead   PermissionSlug = "permission-read"
+	PermissionPermissionCreate PermissionSlug = "permission-create"
+	PermissionPermissionEdit   PermissionSlug = "permission-edit"
+	PermissionPermissionDelete PermissionSlug = "permission-delete"
+
+	PermissionQuestionAll    PermissionSlug = "question-*"
+	PermissionQuestionRead   PermissionSlug = "question-read"
+	PermissionQuestionCreate PermissionSlug = "question-create"
+	PermissionQuestionEdit   PermissionSlug = "question-edit"
+	PermissionQuestionDelete PermissionSlug = "question-delete"
+
+	PermissionRoleAll    PermissionSlug = "role-*"
+	PermissionRoleRead   PermissionSlug = "role-read"
+	PermissionRoleCreate PermissionSlug = "role-create"
+	PermissionRoleEdit   PermissionSlug = "role-edit"
+	PermissionRoleDelete PermissionSlug = "role-delete"
+
+	PermissionSessionTemplateAll    PermissionSlug = "session-template-*"
+	PermissionSessionTemplateRead   PermissionSlug = "session-template-read"
+	PermissionSessionTemplateCreate PermissionSlug = "session-template-create"
+	PermissionSessionTemplateEdit   PermissionSlug = "session-template-edit"
+	PermissionSessionTemplateDelete PermissionSlug = "session-template-delete"
+
+	PermissionUserAll    PermissionSlug = "user-*"
+	PermissionUserRead   PermissionSlug = "user-read"
+	PermissionUserCreate PermissionSlug = "user-create"
+	PermissionUserEdit   PermissionSlug = "user-edit"
+	PermissionUserDelete PermissionSlug = "user-delete"
+)
+
+// permissionNames is the registry of every slug the application knows
+// about, in the order they are listed to admins.
+var permissionNames = []struct {
+	slug PermissionSlug
+	name string
+}{
+	{PermissionAll, "All permissions"},
+
+	{PermissionAuditAll, "Audit all"},
+	{PermissionAuditRead, "Audit read"},
+
+	{PermissionCategoryAll, "Category all"},
+	{PermissionCategoryRead, "Category read"},
+	{PermissionCategoryCreate, "Category create"},
+	{PermissionCategoryEdit, "Category edit"},
+	{PermissionCategoryDelete, "Category delete"},
+
+	{PermissionLockoutAll, "Lockout all"},
+	{PermissionLockoutRead, "Lockout read"},
+	{PermissionLockoutDelete, "Lockout delete"},
+
+	{PermissionPermissionAll, "Permission all"},
+	{PermissionPermissionRead, "Permission read"},
+	{PermissionPermissionCreate, "Permission create"},
+	{PermissionPermissionEdit, "Permission edit"},
+	{PermissionPermissionDelete, "Permission delete"},
+
+	{PermissionQuestionAll, "Question all"},
+	{PermissionQuestionRead, "Question read"},
+	{PermissionQuestionCreate, "Question create"},
+	{PermissionQuestionEdit, "Question edit"},
+	{PermissionQuestionDelete, "Question delete"},
+
+	{PermissionRoleAll, "Role all"},
+	{PermissionRoleRead, "Role read"},
+	{PermissionRoleCreate, "Role create"},
+	{PermissionRoleEdit, "Role edit"},
+	{PermissionRoleDelete, "Role delete"},
+
+	{PermissionSessionTemplateAll, "Session template all"},
+	{PermissionSessionTemplateRead, "Session template read"},
+	{PermissionSessionTemplateCreate, "Session template create"},
+	{PermissionSessionTemplateEdit, "Session template edit"},
+	{PermissionSessionTemplateDelete, "Session template delete"},
+
+	{PermissionUserAll, "User all"},
+	{PermissionUserRead, "User read"},
+	{PermissionUserCreate, "User create"},
+	{PermissionUserEdit, "User edit"},
+	{PermissionUserDelete, "User delete"},
+}
+
+func PermissionSlugs() []PermissionSlug {
+	slugs := make([]PermissionSlug, 0, len(permissionNames))
+	for _, permission := range permissionNames {
+		slugs = append(slugs, permission.slug)
+	}
+
+	return slugs
+}
+
+func (ps PermissionSlug) String() string {
+	return string(ps)
+}
+
+func (ps PermissionSlug) Name() string {
+	for _, permission := range permissionNames {
+		if permission.slug == ps {
+			return permission.name
+		}
+	}
+
+	return ""
+}
+
+func (ps PermissionSlug) IsRegistered() bool {
+	return ps.Name() != ""
+}
+
+func (ps PermissionSlug) IsWildcard() bool {
+	return strings.HasSuffix(string(ps), "*")
+}
+
+// Matches reports whether a granted slug covers slug, "*" covers every
+// slug and "question-*" covers every slug starting with "question-".
+func (ps PermissionSlug) Matches(slug PermissionSlug) bool {
+	if !ps.IsWildcard() {
+		return ps == slug
+	}
+
+	return strings.HasPrefix(string(slug), strings.TrimSuffix(string(ps), "*"))
+}
+
+// Expand returns the registered concrete slugs a granted slug covers.
+func (ps PermissionSlug) Expand() []PermissionSlug {
+	if !ps.IsWildcard() {
+		return []PermissionSlug{ps}
+	}
+
+	var slugs []PermissionSlug
+	for _, permission := range permissionNames {
+		if !permission.slug.IsWildcard() && ps.Matches(permission.slug) {
+			slugs = append(slugs, permission.slug)
+		}
+	}
+
+	return slugs
+}
@@ -8,10 +8,28 @@ const (
 	GradeSenior GradeName = "senior"
 )
 
+var grades = []GradeName{GradeJunior, GradeMiddle, GradeSenior}
+
 func (g GradeName) String() string {
 	return string(g)
 }
 
+func (g GradeName) Level() int {
+	for level, grade := range grades {
+		if grade == g {
+			return level
+		}
+	}
+
+	return -1
+}
+
+func GradeByLevel(level int) GradeName {
+	level = max(0, min(len(grades)-1, level))
+
+	return grades[level]
+}
+
 func ValidateGradeName(value string) (GradeName, error) {
 	grade := GradeName(value)
 	switch grade {
@@ -0,0 +1,30 @@
+package def
+
+type QuestionType string
+
+const (
+	QuestionText           QuestionType = "text"
+	QuestionSingleChoice   QuestionType = "single_choice"
+	QuestionMultipleChoice QuestionType = "multiple_choice"
+	QuestionCode           QuestionType = "code"
+)
+
+func (t QuestionType) String() string {
+	return string(t)
+}
+
+func (t QuestionType) IsChoice() bool {
+	return t == QuestionSingleChoice || t == QuestionMultipleChoice
+}
+
+func ValidateQuestionType(value string) (QuestionType, error) {
+	questionType := QuestionType(value)
+	switch questionType {
+	case QuestionText, QuestionSingleChoice, QuestionMultipleChoice, QuestionCode:
+		return questionType, nil
+	case "":
+		return QuestionText, nil
+	default:
+		return "", ErrInvalidQuestionType
+	}
+}
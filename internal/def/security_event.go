@@ -0,0 +1,11 @@
+package def
+
+type SecurityEventType string
+
+const (
+	SecurityRTokenReused SecurityEventType = "refresh_token_reused"
+)
+
+func (set SecurityEventType) String() string {
+	return string(set)
+}
@@ -0,0 +1,24 @@
+package def
+
+type SessionMode string
+
+const (
+	SessionFixed    SessionMode = "fixed"
+	SessionAdaptive SessionMode = "adaptive"
+)
+
+func (m SessionMode) String() string {
+	return string(m)
+}
+
+func ValidateSessionMode(value string) (SessionMode, error) {
+	mode := SessionMode(value)
+	switch mode {
+	case SessionFixed, SessionAdaptive:
+		return mode, nil
+	case "":
+		return SessionFixed, nil
+	default:
+		return "", ErrInvalidSessionMode
+	}
+}
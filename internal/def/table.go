@@ -11,6 +11,12 @@ const (
 	TableQuestions        TableName = "questions"
 	TableSessions         TableName = "sessions"
 	TableSessionQuestions TableName = "session_questions"
+	TableJobs             TableName = "jobs"
+	TableSessionTemplates TableName = "session_templates"
+	TableSecurityEvents   TableName = "security_events"
+	TablePasswordResets   TableName = "password_resets"
+	TableLockouts         TableName = "lockouts"
+	TableAuditLogs        TableName = "audit_logs"
 )
 
 func (tn TableName) String() string {
@@ -0,0 +1,12 @@
+package def
+
+type TokenAudience string
+
+const (
+	AudienceEmailVerification TokenAudience = "email_verification"
+	AudienceLoginChallenge    TokenAudience = "login_challenge"
+)
+
+func (ta TokenAudience) String() string {
+	return string(ta)
+}
@@ -0,0 +1,24 @@
+package def
+
+type VerdictName string
+
+const (
+	VerdictBelow VerdictName = "below"
+	VerdictMeets VerdictName = "meets"
+	VerdictAbove VerdictName = "above"
+)
+
+func (v VerdictName) String() string {
+	return string(v)
+}
+
+func CompareGrades(recommended, targeted GradeName) VerdictName {
+	switch {
+	case recommended.Level() < targeted.Level():
+		return VerdictBelow
+	case recommended.Level() > targeted.Level():
+		return VerdictAbove
+	default:
+		return VerdictMeets
+	}
+}
@@ -15,6 +15,7 @@ type (
 		IP             string             `json:"ip"`
 		UserID         primitive.ObjectID `json:"user_id"`
 		RefreshTokenID primitive.ObjectID `json:"refresh_token_id"`
+		TokenVersion   int                `json:"token_version"`
 		jwt.RegisteredClaims
 	}
 )
@@ -0,0 +1,8 @@
+package dto
+
+import "tech_check/internal/model"
+
+type CodeRun struct {
+	BuildOutput string
+	Results     []model.TestResult
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "tech_check/internal/model"
+
+type Device struct {
+	model.RefreshToken
+	IsCurrent bool `json:"is_current"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"tech_check/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type (
+	// EffectivePermissions holds global slugs in Slugs and, in Scoped, the
+	// category ids per slug granted only through scoped roles. Slugs may be
+	// wildcards such as "question-*".
+	EffectivePermissions struct {
+		Slugs        []string
+		Scoped       map[string][]string
+		RequiresTOTP bool
+	}
+
+	RolePermissions struct {
+		InheritedRoleIDs []primitive.ObjectID `json:"inherited_role_ids"`
+		Slugs            []string             `json:"slugs"`
+	}
+
+	Me struct {
+		model.User
+		Permissions       []string            `json:"permissions"`
+		ScopedPermissions map[string][]string `json:"scoped_permissions"`
+		TwoFactorRequired bool                `json:"two_factor_required"`
+	}
+)
@@ -0,0 +1,22 @@
+package dto
+
+import "tech_check/internal/model"
+
+type (
+	EvaluationInput struct {
+		Question string
+		Grade    string
+		Category string
+		Answer   string
+		Rubric   model.QuestionRubric
+	}
+
+	Evaluation struct {
+		Summary    string   `json:"summary"`
+		Score      int      `json:"score"`
+		Strengths  []string `json:"strengths"`
+		Weaknesses []string `json:"weaknesses"`
+
+		TestResults []model.TestResult `json:"-"`
+	}
+)
@@ -0,0 +1,8 @@
+package dto
+
+type Mail struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
@@ -0,0 +1,7 @@
+package dto
+
+type OIDCIdentity struct {
+	Email   string
+	Name    string
+	Picture string
+}
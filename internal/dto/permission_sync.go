@@ -0,0 +1,6 @@
+package dto
+
+type PermissionSync struct {
+	Created  []string
+	Orphaned []string
+}
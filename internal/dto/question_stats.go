@@ -0,0 +1,9 @@
+package dto
+
+type QuestionStats struct {
+	Asked           int `bson:"asked" json:"asked"`
+	Answered        int `bson:"answered" json:"answered"`
+	Evaluated       int `bson:"evaluated" json:"evaluated"`
+	AverageScore    int `bson:"average_score" json:"average_score"`
+	AskedBeforeEdit int `bson:"asked_before_edit" json:"asked_before_edit"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type SessionBucket struct {
+	CategoryID string
+	Grade      string
+	Count      int
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type (
+	Challenge struct {
+		ChallengeToken string    `json:"challenge_token"`
+		ExpiresAt      time.Time `json:"expires_at"`
+	}
+
+	TwoFactorEnrollment struct {
+		Secret string `json:"secret"`
+		URI    string `json:"uri"`
+	}
+
+	RecoveryCodes struct {
+		Codes []string `json:"recovery_codes"`
+	}
+)
@@ -0,0 +1,146 @@
+package evaluator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"time"
+)
+
+type (
+	OpenAI struct {
+		url    string
+		apiKey string
+		model  string
+		client *http.Client
+	}
+
+	chatMessage struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+
+	chatResponseFormat struct {
+		Type string `json:"type"`
+	}
+
+	chatRequest struct {
+		Model          string             `json:"model"`
+		Messages       []chatMessage      `json:"messages"`
+		Temperature    float64            `json:"temperature"`
+		ResponseFormat chatResponseFormat `json:"response_format"`
+	}
+
+	chatResponse struct {
+		Choices []struct {
+			Message chatMessage `json:"message"`
+		} `json:"choices"`
+	}
+)
+
+func NewOpenAI(url, apiKey, model string, timeout time.Duration) *OpenAI {
+	return &OpenAI{
+		url:    strings.TrimRight(url, "/"),
+		apiKey: apiKey,
+		model:  model,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (o *OpenAI) Evaluate(ctx context.Context, input *dto.EvaluationInput) (*dto.Evaluation, error) {
+	const op = "evaluator.OpenAI.Evaluate"
+
+	body, err := json.Marshal(chatRequest{
+		Model: o.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: o.systemPrompt()},
+			{Role: "user", Content: o.userPrompt(input)},
+		},
+		Temperature:    0,
+		ResponseFormat: chatResponseFormat{Type: "json_object"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	req.Header.Set(def.HeaderContentType.String(), "application/json")
+	if o.apiKey != "" {
+		req.Header.Set(def.HeaderAuthorization.String(), "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w: status %d", op, def.ErrInvalidEvaluation, resp.StatusCode)
+	}
+
+	var chatResp chatResponse
+	err = json.NewDecoder(resp.Body).Decode(&chatResp)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(chatResp.Choices) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidEvaluation)
+	}
+
+	var evaluation dto.Evaluation
+	err = json.Unmarshal([]byte(chatResp.Choices[0].Message.Content), &evaluation)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, def.ErrInvalidEvaluation, err)
+	}
+
+	evaluation.Score = max(0, min(100, evaluation.Score))
+	if evaluation.Strengths == nil {
+		evaluation.Strengths = []string{}
+	}
+	if evaluation.Weaknesses == nil {
+		evaluation.Weaknesses = []string{}
+	}
+
+	return &evaluation, nil
+}
+
+func (o *OpenAI) systemPrompt() string {
+	return "You are a technical interviewer grading a candidate's answer. " +
+		"Respond only with a JSON object of the form " +
+		`{"summary": string, "score": integer from 0 to 100, "strengths": [string], "weaknesses": [string]}. ` +
+		"The summary is short feedback addressed to the candidate."
+}
+
+func (o *OpenAI) userPrompt(input *dto.EvaluationInput) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Category: %s\n", input.Category)
+	fmt.Fprintf(&sb, "Expected grade: %s\n", input.Grade)
+	fmt.Fprintf(&sb, "Question: %s\n", input.Question)
+	if input.Rubric.ReferenceAnswer != "" {
+		fmt.Fprintf(&sb, "Reference answer: %s\n", input.Rubric.ReferenceAnswer)
+	}
+	if len(input.Rubric.KeyPoints) > 0 {
+		sb.WriteString("Expected key points:\n")
+		for _, keyPoint := range input.Rubric.KeyPoints {
+			fmt.Fprintf(&sb, "- %s\n", keyPoint)
+		}
+	}
+	if len(input.Rubric.Criteria) > 0 {
+		sb.WriteString("Grading criteria (weight):\n")
+		for _, criterion := range input.Rubric.Criteria {
+			fmt.Fprintf(&sb, "- %s (%d)\n", criterion.Name, criterion.Weight)
+		}
+	}
+	fmt.Fprintf(&sb, "Answer: %s\n", input.Answer)
+
+	return sb.String()
+}
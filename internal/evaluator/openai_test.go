@@ -0,0 +1,191 @@
+package evaluator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"testing"
+	"time"
+)
+
+func newStub(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/chat/completions" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get(def.HeaderAuthorization.String()); got != "Bearer key" {
+			t.Errorf("Authorization = %q", got)
+		}
+
+		var req chatRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		if req.Model != "model" || len(req.Messages) != 2 || !strings.Contains(req.Messages[1].Content, "Answer: goroutines") {
+			t.Errorf("unexpected request body %+v", req)
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func chatBody(t *testing.T, content string) string {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]interface{}{
+		"choices": []map[string]interface{}{
+			{"message": map[string]string{"role": "assistant", "content": content}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(body)
+}
+
+func TestOpenAIEvaluate(t *testing.T) {
+	input := &dto.EvaluationInput{
+		Question: "What is a goroutine?",
+		Grade:    "junior",
+		Category: "golang",
+		Answer:   "goroutines are lightweight threads",
+	}
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    *dto.Evaluation
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   chatBody(t, `{"summary": "Good", "score": 140, "strengths": ["clear"]}`),
+			want: &dto.Evaluation{
+				Summary:    "Good",
+				Score:      100,
+				Strengths:  []string{"clear"},
+				Weaknesses: []string{},
+			},
+		},
+		{
+			name:    "non 2xx status",
+			status:  http.StatusInternalServerError,
+			body:    `{"error": "boom"}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed response",
+			status:  http.StatusOK,
+			body:    `{"choices": [`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed evaluation",
+			status:  http.StatusOK,
+			body:    chatBody(t, "not json"),
+			wantErr: true,
+		},
+		{
+			name:    "no choices",
+			status:  http.StatusOK,
+			body:    `{"choices": []}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStub(t, tt.status, tt.body)
+			openAI := NewOpenAI(server.URL+"/", "key", "model", time.Second)
+
+			evaluation, err := openAI.Evaluate(context.Background(), input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Evaluate() = %+v, want error", evaluation)
+				}
+				if tt.status != http.StatusOK && !errors.Is(err, def.ErrInvalidEvaluation) {
+					t.Errorf("Evaluate() error = %v, want ErrInvalidEvaluation", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+
+			if evaluation.Summary != tt.want.Summary ||
+				evaluation.Score != tt.want.Score ||
+				strings.Join(evaluation.Strengths, ",") != strings.Join(tt.want.Strengths, ",") ||
+				evaluation.Weaknesses == nil {
+				t.Errorf("Evaluate() = %+v, want %+v", evaluation, tt.want)
+			}
+		})
+	}
+}
+
+// TestOpenAIAgainstRubricStub serves the offline rubric evaluation through
+// an OpenAI-compatible stub, so the client is checked end to end without
+// network access.
+func TestOpenAIAgainstRubricStub(t *testing.T) {
+	rubric := NewRubric()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		prompt := req.Messages[len(req.Messages)-1].Content
+		answer := prompt[strings.LastIndex(prompt, "Answer: ")+len("Answer: "):]
+		evaluation, err := rubric.Evaluate(r.Context(), &dto.EvaluationInput{
+			Question: "What is a goroutine?",
+			Grade:    "junior",
+			Answer:   answer,
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		content, _ := json.Marshal(evaluation)
+		w.Write([]byte(chatBody(t, string(content))))
+	}))
+	defer server.Close()
+
+	input := &dto.EvaluationInput{
+		Question: "What is a goroutine?",
+		Grade:    "junior",
+		Answer:   "A goroutine is a lightweight thread of execution managed by the Go runtime.",
+	}
+	want, err := rubric.Evaluate(context.Background(), &dto.EvaluationInput{
+		Question: input.Question,
+		Grade:    input.Grade,
+		Answer:   input.Answer + "\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := NewOpenAI(server.URL, "", "model", time.Second).Evaluate(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if got.Score != want.Score || got.Summary != want.Summary {
+		t.Errorf("Evaluate() = %+v, want %+v", got, want)
+	}
+}
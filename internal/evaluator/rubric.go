@@ -0,0 +1,278 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"unicode"
+)
+
+type (
+	Rubric struct {
+		minKeywordLength int
+		contentWeight    float64
+		coveredRatio     float64
+		expectedWords    map[def.GradeName]int
+		stopWords        map[string]struct{}
+	}
+
+	check struct {
+		ratio      float64
+		isScored   bool
+		strengths  []string
+		weaknesses []string
+	}
+)
+
+func NewRubric() *Rubric {
+	stopWords := make(map[string]struct{})
+	for _, word := range []string{
+		"about", "after", "also", "and", "are", "because", "been", "before", "between",
+		"can", "could", "describe", "does", "each", "explain", "from", "give", "have",
+		"how", "into", "its", "list", "more", "most", "name", "other", "should", "some",
+		"such", "than", "that", "the", "their", "them", "then", "there", "these", "they",
+		"this", "those", "what", "when", "where", "which", "while", "who", "why", "will",
+		"with", "would", "your", "question", "category", "test",
+	} {
+		stopWords[word] = struct{}{}
+	}
+
+	return &Rubric{
+		minKeywordLength: 4,
+		contentWeight:    0.6,
+		coveredRatio:     0.5,
+		expectedWords: map[def.GradeName]int{
+			def.GradeJunior: 15,
+			def.GradeMiddle: 30,
+			def.GradeSenior: 50,
+		},
+		stopWords: stopWords,
+	}
+}
+
+func (r *Rubric) Evaluate(ctx context.Context, input *dto.EvaluationInput) (*dto.Evaluation, error) {
+	answerWords := r.words(input.Answer)
+	if len(answerWords) == 0 {
+		return &dto.Evaluation{
+			Summary:    "No answer was given.",
+			Score:      0,
+			Strengths:  []string{},
+			Weaknesses: []string{"answer is empty"},
+		}, nil
+	}
+
+	answerSet := make(map[string]struct{}, len(answerWords))
+	for _, word := range answerWords {
+		answerSet[word] = struct{}{}
+	}
+
+	checks := []check{}
+	switch {
+	case len(input.Rubric.KeyPoints) > 0:
+		checks = append(checks, r.checkKeyPoints(input.Rubric.KeyPoints, answerSet))
+	case input.Rubric.ReferenceAnswer != "":
+		checks = append(checks, r.checkReference(input.Rubric.ReferenceAnswer, answerSet))
+	default:
+		checks = append(checks, r.checkKeywords(input.Question, answerSet))
+	}
+	if len(input.Rubric.Criteria) > 0 {
+		checks = append(checks, r.checkCriteria(input.Rubric.Criteria, answerSet))
+	}
+
+	strengths := []string{}
+	weaknesses := []string{}
+	var contentRatios []float64
+	for _, c := range checks {
+		strengths = append(strengths, c.strengths...)
+		weaknesses = append(weaknesses, c.weaknesses...)
+		if c.isScored {
+			contentRatios = append(contentRatios, c.ratio)
+		}
+	}
+
+	expected, ok := r.expectedWords[def.GradeName(input.Grade)]
+	if !ok {
+		expected = r.expectedWords[def.GradeMiddle]
+	}
+	lengthRatio := math.Min(1, float64(len(answerWords))/float64(expected))
+
+	contentRatio := lengthRatio
+	if len(contentRatios) > 0 {
+		contentRatio = 0
+		for _, ratio := range contentRatios {
+			contentRatio += ratio
+		}
+		contentRatio /= float64(len(contentRatios))
+	}
+
+	score := int(math.Round(100 * (r.contentWeight*contentRatio + (1-r.contentWeight)*lengthRatio)))
+
+	if lengthRatio >= 1 {
+		strengths = append(strengths, fmt.Sprintf("answer is detailed enough for %s level", input.Grade))
+	} else {
+		weaknesses = append(weaknesses, fmt.Sprintf("answer is too short for %s level", input.Grade))
+	}
+
+	return &dto.Evaluation{
+		Summary:    r.summary(score, input.Category),
+		Score:      score,
+		Strengths:  strengths,
+		Weaknesses: weaknesses,
+	}, nil
+}
+
+func (r *Rubric) checkKeyPoints(keyPoints []string, answerSet map[string]struct{}) check {
+	var covered, missed []string
+	for _, keyPoint := range keyPoints {
+		if r.coverage(r.keywords(keyPoint), answerSet) >= r.coveredRatio {
+			covered = append(covered, keyPoint)
+		} else {
+			missed = append(missed, keyPoint)
+		}
+	}
+
+	c := check{
+		ratio:    float64(len(covered)) / float64(len(keyPoints)),
+		isScored: true,
+	}
+	if len(covered) > 0 {
+		c.strengths = append(c.strengths, fmt.Sprintf("covers key points: %s", strings.Join(covered, "; ")))
+	}
+	if len(missed) > 0 {
+		c.weaknesses = append(c.weaknesses, fmt.Sprintf("misses key points: %s", strings.Join(missed, "; ")))
+	}
+
+	return c
+}
+
+func (r *Rubric) checkReference(reference string, answerSet map[string]struct{}) check {
+	ratio := r.coverage(r.keywords(reference), answerSet)
+
+	c := check{
+		ratio:    math.Min(1, ratio/r.coveredRatio),
+		isScored: true,
+	}
+	if ratio >= r.coveredRatio {
+		c.strengths = append(c.strengths, "answer is close to the reference answer")
+	} else {
+		c.weaknesses = append(c.weaknesses, "answer differs from the reference answer")
+	}
+
+	return c
+}
+
+func (r *Rubric) checkKeywords(question string, answerSet map[string]struct{}) check {
+	var covered, missed []string
+	for _, keyword := range r.keywords(question) {
+		if _, ok := answerSet[keyword]; ok {
+			covered = append(covered, keyword)
+		} else {
+			missed = append(missed, keyword)
+		}
+	}
+
+	c := check{}
+	if len(covered)+len(missed) > 0 {
+		c.ratio = float64(len(covered)) / float64(len(covered)+len(missed))
+		c.isScored = true
+	}
+	if len(covered) > 0 {
+		c.strengths = append(c.strengths, fmt.Sprintf("covers key terms: %s", strings.Join(covered, ", ")))
+	}
+	if len(missed) > 0 {
+		c.weaknesses = append(c.weaknesses, fmt.Sprintf("does not mention: %s", strings.Join(missed, ", ")))
+	}
+
+	return c
+}
+
+func (r *Rubric) checkCriteria(criteria []model.QuestionCriterion, answerSet map[string]struct{}) check {
+	var met, unmet []string
+	weightSum, metWeight := 0, 0
+	for _, criterion := range criteria {
+		weightSum += criterion.Weight
+		if r.coverage(r.keywords(criterion.Name), answerSet) >= r.coveredRatio {
+			metWeight += criterion.Weight
+			met = append(met, criterion.Name)
+		} else {
+			unmet = append(unmet, criterion.Name)
+		}
+	}
+
+	c := check{}
+	if weightSum > 0 {
+		c.ratio = float64(metWeight) / float64(weightSum)
+		c.isScored = true
+	}
+	if len(met) > 0 {
+		c.strengths = append(c.strengths, fmt.Sprintf("meets criteria: %s", strings.Join(met, "; ")))
+	}
+	if len(unmet) > 0 {
+		c.weaknesses = append(c.weaknesses, fmt.Sprintf("does not meet criteria: %s", strings.Join(unmet, "; ")))
+	}
+
+	return c
+}
+
+func (r *Rubric) summary(score int, category string) string {
+	var verdict string
+	switch {
+	case score >= 80:
+		verdict = "Strong answer"
+	case score >= 50:
+		verdict = "Acceptable answer with gaps"
+	default:
+		verdict = "Weak answer"
+	}
+
+	if category == "" {
+		return fmt.Sprintf("%s (score %d/100).", verdict, score)
+	}
+
+	return fmt.Sprintf("%s on %s (score %d/100).", verdict, category, score)
+}
+
+func (r *Rubric) coverage(keywords []string, answerSet map[string]struct{}) float64 {
+	if len(keywords) == 0 {
+		return 1
+	}
+
+	found := 0
+	for _, keyword := range keywords {
+		if _, ok := answerSet[keyword]; ok {
+			found++
+		}
+	}
+
+	return float64(found) / float64(len(keywords))
+}
+
+func (r *Rubric) keywords(text string) []string {
+	seen := make(map[string]struct{})
+	var keywords []string
+	for _, word := range r.words(text) {
+		if len([]rune(word)) < r.minKeywordLength {
+			continue
+		}
+		if _, ok := r.stopWords[word]; ok {
+			continue
+		}
+		if _, ok := seen[word]; ok {
+			continue
+		}
+		seen[word] = struct{}{}
+		keywords = append(keywords, word)
+	}
+
+	return keywords
+}
+
+func (r *Rubric) words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+}
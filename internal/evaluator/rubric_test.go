@@ -0,0 +1,111 @@
+package evaluator
+
+import (
+	"context"
+	"strings"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"testing"
+)
+
+func TestRubricEvaluate(t *testing.T) {
+	rubric := NewRubric()
+
+	tests := []struct {
+		name      string
+		input     dto.EvaluationInput
+		wantScore func(score int) bool
+	}{
+		{
+			name: "empty answer",
+			input: dto.EvaluationInput{
+				Question: "What is a goroutine?",
+				Grade:    "junior",
+				Answer:   "  ",
+			},
+			wantScore: func(score int) bool { return score == 0 },
+		},
+		{
+			name: "covers every key point",
+			input: dto.EvaluationInput{
+				Question: "What is a goroutine?",
+				Grade:    "junior",
+				Answer: "A goroutine is a lightweight thread managed by the Go runtime scheduler, " +
+					"it starts with a small stack that grows on demand and many goroutines share os threads.",
+				Rubric: model.QuestionRubric{
+					KeyPoints: []string{"lightweight thread", "runtime scheduler", "small stack"},
+				},
+			},
+			wantScore: func(score int) bool { return score == 100 },
+		},
+		{
+			name: "misses key points",
+			input: dto.EvaluationInput{
+				Question: "What is a goroutine?",
+				Grade:    "senior",
+				Answer:   "It is a function.",
+				Rubric: model.QuestionRubric{
+					KeyPoints: []string{"lightweight thread", "runtime scheduler"},
+				},
+			},
+			wantScore: func(score int) bool { return score < 50 },
+		},
+		{
+			name: "weighted criteria",
+			input: dto.EvaluationInput{
+				Question: "How do channels work?",
+				Grade:    "junior",
+				Answer:   "Channels block the sender until a receiver is ready when they are unbuffered, buffered ones block only when full.",
+				Rubric: model.QuestionRubric{
+					ReferenceAnswer: "Unbuffered channels block until receiver ready, buffered channels block when full.",
+					Criteria: []model.QuestionCriterion{
+						{Name: "unbuffered blocking", Weight: 3},
+						{Name: "select statement", Weight: 1},
+					},
+				},
+			},
+			wantScore: func(score int) bool { return score >= 80 && score < 100 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluation, err := rubric.Evaluate(context.Background(), &tt.input)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if !tt.wantScore(evaluation.Score) {
+				t.Errorf("Evaluate() score = %d, summary %q", evaluation.Score, evaluation.Summary)
+			}
+			if evaluation.Strengths == nil || evaluation.Weaknesses == nil {
+				t.Errorf("Evaluate() strengths and weaknesses must not be nil")
+			}
+		})
+	}
+}
+
+func TestRubricEvaluateIsDeterministic(t *testing.T) {
+	rubric := NewRubric()
+	input := dto.EvaluationInput{
+		Question: "Explain the difference between a slice and an array.",
+		Grade:    "middle",
+		Category: "golang",
+		Answer:   "An array has a fixed length that is part of its type, a slice is a view over an array with length and capacity.",
+	}
+
+	first, err := rubric.Evaluate(context.Background(), &input)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	second, err := rubric.Evaluate(context.Background(), &input)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+
+	if first.Score != second.Score || first.Summary != second.Summary {
+		t.Errorf("Evaluate() is not deterministic: %+v != %+v", first, second)
+	}
+	if !strings.Contains(first.Summary, "golang") {
+		t.Errorf("Evaluate() summary %q does not mention the category", first.Summary)
+	}
+}
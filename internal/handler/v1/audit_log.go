@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"tech_check/internal/def"
+	"tech_check/internal/handler/v1/mwr"
+	"tech_check/internal/handler/v1/request"
+	"tech_check/internal/handler/v1/response"
+)
+
+type auditLog struct {
+	auditLogSrvc AuditLogSrvc
+}
+
+func newAuditLog(
+	mux *http.ServeMux,
+	authMwr *mwr.Auth,
+	permissionMwr *mwr.Permission,
+	auditLogSrvc AuditLogSrvc,
+) {
+	a := auditLog{
+		auditLogSrvc: auditLogSrvc,
+	}
+
+	mux.HandleFunc(
+		Url(http.MethodGet, "/audit-logs"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(a.list, def.PermissionAuditRead)),
+	)
+}
+
+// @Summary audit logs list
+// @Tags audit-logs
+// @Security BearerAuth
+// @Router /v1/audit-logs [get]
+// @Param pagination[page] query int false "page"
+// @Param pagination[count] query int false "count"
+// @Param sorts[created_at] query string false "created_at" Enums(asc, desc)
+// @Param filters[actor_id] query string false "comma separated actor ids"
+// @Param filters[target_type] query string false "target type" Enums(role, user, question)
+// @Param filters[target_id] query string false "comma separated target ids"
+// @Param filters[action] query string false "action"
+// @Param filters[request_id] query string false "request id"
+// @Param filters[from] query string false "created at or after, RFC 3339"
+// @Param filters[to] query string false "created at or before, RFC 3339"
+// @Produce json
+// @Success 200 {object} response.list{data=[]model.AuditLog,pagination=dto.Pagination}
+func (a *auditLog) list(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auditLog.list"
+
+	search := request.GetQuerySearch(r)
+	auditLogs, pagination, err := a.auditLogSrvc.List(
+		r.Context(),
+		search.Pagination.Page,
+		search.Pagination.Count,
+		search.Filters,
+		search.Sorts,
+	)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonList(w, r, auditLogs, pagination)
+}
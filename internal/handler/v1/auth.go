@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"tech_check/internal/def"
 	"tech_check/internal/handler/v1/mwr"
 	"tech_check/internal/handler/v1/request"
 	"tech_check/internal/handler/v1/response"
@@ -23,8 +24,18 @@ func newAuth(
 
 	mux.HandleFunc(Url(http.MethodPost, "/auth"), a.login)
 	mux.HandleFunc(Url(http.MethodPost, "/auth/google"), a.googleLogin)
+	mux.HandleFunc(Url(http.MethodPost, "/auth/oidc/{provider}"), a.oidcLogin)
+	mux.HandleFunc(Url(http.MethodPost, "/auth/challenge"), a.verifyChallenge)
 	mux.HandleFunc(Url(http.MethodGet, "/auth"), authMwr.MwrFunc(a.me))
 	mux.HandleFunc(Url(http.MethodPost, "/auth/refresh"), a.refresh)
+	mux.HandleFunc(Url(http.MethodPost, "/auth/password/forgot"), a.forgotPassword)
+	mux.HandleFunc(Url(http.MethodPost, "/auth/password/reset"), a.resetPassword)
+	mux.HandleFunc(Url(http.MethodPatch, "/auth/password"), authMwr.MwrFunc(a.changePassword))
+	mux.HandleFunc(Url(http.MethodPost, "/auth/logout"), authMwr.MwrFunc(a.logout))
+	mux.HandleFunc(Url(http.MethodPost, "/auth/logout-all"), authMwr.MwrFunc(a.logoutAll))
+	mux.HandleFunc(Url(http.MethodGet, "/auth/devices"), authMwr.MwrFunc(a.devices))
+	mux.HandleFunc(Url(http.MethodDelete, "/auth/devices/{id}"), authMwr.MwrFunc(a.revokeDevice))
+	mux.HandleFunc(http.MethodGet+" /.well-known/jwks.json", a.jwks)
 }
 
 // @Summary login
@@ -34,6 +45,7 @@ func newAuth(
 // @Param body body request.Login true "login request"
 // @Produce json
 // @Success 200 {object} response.success{data=dto.Token}
+// @Success 202 {object} response.success{data=dto.Challenge}
 func (a *auth) login(w http.ResponseWriter, r *http.Request) {
 	const op = "v1.auth.login"
 
@@ -44,12 +56,17 @@ func (a *auth) login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := a.authSrvc.Login(r.Context(), req.Email, req.Password, request.GetHeaderIP(r))
+	token, challenge, err := a.authSrvc.Login(r.Context(), req.Email, req.Password, request.GetHeaderIP(r), request.GetHeaderUserAgent(r))
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 		return
 	}
 
+	if challenge != nil {
+		response.JsonSuccess(w, r, http.StatusAccepted, challenge)
+		return
+	}
+
 	response.JsonSuccess(w, r, http.StatusOK, token)
 }
 
@@ -60,6 +77,7 @@ func (a *auth) login(w http.ResponseWriter, r *http.Request) {
 // @Param body body request.GoogleLogin true "google login request"
 // @Produce json
 // @Success 200 {object} response.success{data=dto.Token}
+// @Success 202 {object} response.success{data=dto.Challenge}
 func (a *auth) googleLogin(w http.ResponseWriter, r *http.Request) {
 	const op = "v1.auth.googleLogin"
 
@@ -70,7 +88,73 @@ func (a *auth) googleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := a.authSrvc.GoogleLogin(r.Context(), req.TokenID, request.GetHeaderIP(r))
+	token, challenge, err := a.authSrvc.GoogleLogin(r.Context(), req.TokenID, request.GetHeaderIP(r), request.GetHeaderUserAgent(r))
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	if challenge != nil {
+		response.JsonSuccess(w, r, http.StatusAccepted, challenge)
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, token)
+}
+
+// @Summary oidc login
+// @Tags auth
+// @Router /v1/auth/oidc/{provider} [post]
+// @Accept json
+// @Param provider path string true "provider name"
+// @Param body body request.OIDCLogin true "oidc login request"
+// @Produce json
+// @Success 200 {object} response.success{data=dto.Token}
+// @Success 202 {object} response.success{data=dto.Challenge}
+func (a *auth) oidcLogin(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auth.oidcLogin"
+
+	provider := r.PathValue("provider")
+
+	var req request.OIDCLogin
+	err := request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	token, challenge, err := a.authSrvc.OIDCLogin(r.Context(), provider, req.IDToken, request.GetHeaderIP(r), request.GetHeaderUserAgent(r))
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	if challenge != nil {
+		response.JsonSuccess(w, r, http.StatusAccepted, challenge)
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, token)
+}
+
+// @Summary verify login challenge with two-factor code
+// @Tags auth
+// @Router /v1/auth/challenge [post]
+// @Accept json
+// @Param body body request.LoginChallenge true "login challenge request"
+// @Produce json
+// @Success 200 {object} response.success{data=dto.Token}
+func (a *auth) verifyChallenge(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auth.verifyChallenge"
+
+	var req request.LoginChallenge
+	err := request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	token, err := a.authSrvc.VerifyChallenge(r.Context(), req.ChallengeToken, req.Code, request.GetHeaderIP(r), request.GetHeaderUserAgent(r))
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 		return
@@ -84,7 +168,7 @@ func (a *auth) googleLogin(w http.ResponseWriter, r *http.Request) {
 // @Security BearerAuth
 // @Router /v1/auth [get]
 // @Produce json
-// @Success 200 {object} response.success{data=model.User}
+// @Success 200 {object} response.success{data=dto.Me}
 func (a *auth) me(w http.ResponseWriter, r *http.Request) {
 	const op = "v1.auth.me"
 
@@ -94,7 +178,13 @@ func (a *auth) me(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JsonSuccess(w, r, http.StatusOK, user)
+	me, err := a.authSrvc.Me(r.Context(), user)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, me)
 }
 
 // @Summary refresh token
@@ -114,7 +204,7 @@ func (a *auth) refresh(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := a.authSrvc.Refresh(r.Context(), req.AToken, req.RToken, request.GetHeaderIP(r))
+	token, err := a.authSrvc.Refresh(r.Context(), req.AToken, req.RToken, request.GetHeaderIP(r), request.GetHeaderUserAgent(r))
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 		return
@@ -122,3 +212,199 @@ func (a *auth) refresh(w http.ResponseWriter, r *http.Request) {
 
 	response.JsonSuccess(w, r, http.StatusOK, token)
 }
+
+// @Summary request password reset
+// @Tags auth
+// @Router /v1/auth/password/forgot [post]
+// @Accept json
+// @Param body body request.PasswordForgot true "password forgot request"
+// @Success 204
+func (a *auth) forgotPassword(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auth.forgotPassword"
+
+	var req request.PasswordForgot
+	err := request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	err = a.authSrvc.ForgotPassword(r.Context(), req.Email, request.GetHeaderIP(r))
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
+
+// @Summary reset password
+// @Tags auth
+// @Router /v1/auth/password/reset [post]
+// @Accept json
+// @Param body body request.PasswordReset true "password reset request"
+// @Success 204
+func (a *auth) resetPassword(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auth.resetPassword"
+
+	var req request.PasswordReset
+	err := request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	err = a.authSrvc.ResetPassword(r.Context(), req.Token, req.Password, request.GetHeaderIP(r))
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
+
+// @Summary change auth user password
+// @Tags auth
+// @Security BearerAuth
+// @Router /v1/auth/password [patch]
+// @Accept json
+// @Param body body request.PasswordChange true "password change request"
+// @Success 204
+func (a *auth) changePassword(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auth.changePassword"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	var req request.PasswordChange
+	err = request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	err = a.authSrvc.ChangePassword(r.Context(), user, req.CurrentPassword, req.NewPassword, request.GetHeaderIP(r))
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
+
+// @Summary logout from current device
+// @Tags auth
+// @Security BearerAuth
+// @Router /v1/auth/logout [post]
+// @Success 204
+func (a *auth) logout(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auth.logout"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	claims, err := request.GetAuthClaims(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	err = a.authSrvc.Logout(r.Context(), user, claims.RefreshTokenID.Hex())
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
+
+// @Summary logout from all devices
+// @Tags auth
+// @Security BearerAuth
+// @Router /v1/auth/logout-all [post]
+// @Success 204
+func (a *auth) logoutAll(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auth.logoutAll"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	err = a.authSrvc.LogoutAll(r.Context(), user)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
+
+// @Summary get auth user devices
+// @Tags auth
+// @Security BearerAuth
+// @Router /v1/auth/devices [get]
+// @Produce json
+// @Success 200 {object} response.success{data=[]dto.Device}
+func (a *auth) devices(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auth.devices"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	claims, err := request.GetAuthClaims(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	devices, err := a.authSrvc.Devices(r.Context(), user, claims.RefreshTokenID.Hex())
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, devices)
+}
+
+// @Summary revoke auth user device
+// @Tags auth
+// @Security BearerAuth
+// @Router /v1/auth/devices/{id} [delete]
+// @Param id path string true "device id"
+// @Success 204
+func (a *auth) revokeDevice(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.auth.revokeDevice"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	id := r.PathValue("id")
+	err = a.authSrvc.RevokeDevice(r.Context(), user, id)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
+
+// jwks is served outside of /api so that other services can discover it at
+// the standard location, the body is a plain JWK set without the data envelope.
+func (a *auth) jwks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(def.HeaderCacheControl.String(), "public, max-age=300")
+	response.Json(w, http.StatusOK, a.authSrvc.JWKS())
+}
@@ -3,6 +3,8 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"tech_check/internal/def"
 	"tech_check/internal/handler/v1/mwr"
 	"tech_check/internal/handler/v1/request"
 	"tech_check/internal/handler/v1/response"
@@ -24,12 +26,12 @@ func newCategory(
 
 	mux.HandleFunc(
 		Url(http.MethodGet, "/categories"),
-		authMwr.MwrFunc(c.list),
+		authMwr.MwrFunc(permissionMwr.FilterMwrFunc(c.list)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodPost, "/categories"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(c.create, "category-create")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(c.create, def.PermissionCategoryCreate)),
 	)
 
 	mux.HandleFunc(
@@ -39,12 +41,12 @@ func newCategory(
 
 	mux.HandleFunc(
 		Url(http.MethodPatch, "/categories/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(c.update, "category-edit")),
+		authMwr.MwrFunc(permissionMwr.ScopedMwrFunc(c.update, def.PermissionCategoryEdit, mwr.PathScope("id"))),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodDelete, "/categories/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(c.delete, "category-delete")),
+		authMwr.MwrFunc(permissionMwr.ScopedMwrFunc(c.delete, def.PermissionCategoryDelete, mwr.PathScope("id"))),
 	)
 }
 
@@ -61,12 +63,18 @@ func newCategory(
 // @Param filters[name] query string false "name"
 // @Param filters[slug] query string false "slug"
 // @Param filters[description] query string false "description"
+// @Param filters[ids] query string false "comma separated category ids"
+// @Param filters[permission] query string false "only categories the user holds this permission for"
 // @Produce json
 // @Success 200 {object} response.list{data=[]model.Category,pagination=dto.Pagination}
 func (c *category) list(w http.ResponseWriter, r *http.Request) {
 	const op = "v1.category.list"
 
 	search := request.GetQuerySearch(r)
+	categoryIDs, ok := request.GetCategoryScope(r)
+	if ok {
+		search.Filters["ids"] = strings.Join(categoryIDs, ",")
+	}
 	categories, pagination, err := c.categorySrvc.List(
 		r.Context(),
 		search.Pagination.Page,
@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"tech_check/internal/def"
+	"tech_check/internal/handler/v1/mwr"
+	"tech_check/internal/handler/v1/request"
+	"tech_check/internal/handler/v1/response"
+)
+
+type lockout struct {
+	lockoutSrvc LockoutSrvc
+}
+
+func newLockout(
+	mux *http.ServeMux,
+	authMwr *mwr.Auth,
+	permissionMwr *mwr.Permission,
+	lockoutSrvc LockoutSrvc,
+) {
+	l := lockout{
+		lockoutSrvc: lockoutSrvc,
+	}
+
+	mux.HandleFunc(
+		Url(http.MethodGet, "/lockouts"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(l.list, def.PermissionLockoutRead)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodGet, "/lockouts/{id}"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(l.show, def.PermissionLockoutRead)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodDelete, "/lockouts/{id}"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(l.delete, def.PermissionLockoutDelete)),
+	)
+}
+
+// @Summary lockouts list
+// @Tags lockouts
+// @Security BearerAuth
+// @Router /v1/lockouts [get]
+// @Param pagination[page] query int false "page"
+// @Param pagination[count] query int false "count"
+// @Param sorts[created_at] query string false "created_at" Enums(asc, desc)
+// @Param sorts[updated_at] query string false "updated_at" Enums(asc, desc)
+// @Param sorts[failures] query string false "failures" Enums(asc, desc)
+// @Param sorts[locked_until] query string false "locked_until" Enums(asc, desc)
+// @Param filters[kind] query string false "kind" Enums(account, ip)
+// @Param filters[key] query string false "email or ip"
+// @Param filters[is_locked] query string false "only active locks" Enums(1)
+// @Produce json
+// @Success 200 {object} response.list{data=[]model.Lockout,pagination=dto.Pagination}
+func (l *lockout) list(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.lockout.list"
+
+	search := request.GetQuerySearch(r)
+	lockouts, pagination, err := l.lockoutSrvc.List(
+		r.Context(),
+		search.Pagination.Page,
+		search.Pagination.Count,
+		search.Filters,
+		search.Sorts,
+	)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonList(w, r, lockouts, pagination)
+}
+
+// @Summary get lockout by id
+// @Tags lockouts
+// @Security BearerAuth
+// @Router /v1/lockouts/{id} [get]
+// @Param id path string true "lockout id"
+// @Produce json
+// @Success 200 {object} response.success{data=model.Lockout}
+func (l *lockout) show(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.lockout.show"
+
+	id := r.PathValue("id")
+	lockout, err := l.lockoutSrvc.GetByID(r.Context(), id)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, lockout)
+}
+
+// @Summary clear lockout by id
+// @Tags lockouts
+// @Security BearerAuth
+// @Router /v1/lockouts/{id} [delete]
+// @Param id path string true "lockout id"
+// @Success 204
+func (l *lockout) delete(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.lockout.delete"
+
+	id := r.PathValue("id")
+
+	err := l.lockoutSrvc.Delete(r.Context(), id)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
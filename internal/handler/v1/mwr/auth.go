@@ -62,7 +62,13 @@ func (a *Auth) MwrFunc(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		if claims.TokenVersion != user.TokenVersion {
+			response.JsonFail(w, r, fmt.Errorf("%s: %w", op, def.ErrATokenRevoked))
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), def.ContextAuthUser, user)
+		ctx = context.WithValue(ctx, def.ContextAuthClaims, claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	}
 }
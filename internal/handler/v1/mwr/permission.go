@@ -1,6 +1,7 @@
 package mwr
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"tech_check/internal/def"
@@ -8,9 +9,14 @@ import (
 	"tech_check/internal/handler/v1/response"
 )
 
-type Permission struct {
-	userSrvc UserSrvc
-}
+type (
+	Permission struct {
+		userSrvc UserSrvc
+	}
+
+	// ScopeFunc returns the id of the category the request targets.
+	ScopeFunc func(r *http.Request) (string, error)
+)
 
 func NewPermission(userSrvc UserSrvc) *Permission {
 	return &Permission{
@@ -18,8 +24,110 @@ func NewPermission(userSrvc UserSrvc) *Permission {
 	}
 }
 
-func (p *Permission) MwrFunc(next http.HandlerFunc, permissionSlug string) http.HandlerFunc {
+// MwrFunc requires a global grant. It panics on a slug missing from the def
+// registry, routes are registered on startup so a typo stops the server
+// from booting.
+func (p *Permission) MwrFunc(next http.HandlerFunc, permissionSlug def.PermissionSlug) http.HandlerFunc {
 	const op = "v1.mwr.Permission.MwrFunc"
+	if !permissionSlug.IsRegistered() {
+		panic(fmt.Sprintf("%s: permission %q is not registered", op, permissionSlug))
+	}
+
+	return p.check(next, permissionSlug, nil)
+}
+
+// ScopedMwrFunc also accepts a grant scoped to the category returned by scope.
+func (p *Permission) ScopedMwrFunc(next http.HandlerFunc, permissionSlug def.PermissionSlug, scope ScopeFunc) http.HandlerFunc {
+	const op = "v1.mwr.Permission.ScopedMwrFunc"
+	if !permissionSlug.IsRegistered() {
+		panic(fmt.Sprintf("%s: permission %q is not registered", op, permissionSlug))
+	}
+
+	return p.check(next, permissionSlug, scope)
+}
+
+// ListMwrFunc requires a grant for the list, either global or for at least
+// one category. A scoped grant limits the list to its categories.
+func (p *Permission) ListMwrFunc(next http.HandlerFunc, permissionSlug def.PermissionSlug) http.HandlerFunc {
+	const op = "v1.mwr.Permission.ListMwrFunc"
+	if !permissionSlug.IsRegistered() {
+		panic(fmt.Sprintf("%s: permission %q is not registered", op, permissionSlug))
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		p.scope(w, r, next, permissionSlug, true)
+	}
+}
+
+// FilterMwrFunc handles the filters[permission] query of list endpoints, the
+// categories the user holds that permission for are put into the context
+// unless it is granted globally.
+func (p *Permission) FilterMwrFunc(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		permissionSlug := def.PermissionSlug(request.GetQueryMap(r, "filters")["permission"])
+		if permissionSlug == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		p.scope(w, r, next, permissionSlug, false)
+	}
+}
+
+func PathScope(name string) ScopeFunc {
+	return func(r *http.Request) (string, error) {
+		return r.PathValue(name), nil
+	}
+}
+
+// scope narrows the categories in the context down to the ones the user
+// holds permissionSlug for, a global grant leaves them as they are.
+func (p *Permission) scope(w http.ResponseWriter, r *http.Request, next http.HandlerFunc, permissionSlug def.PermissionSlug, isRequired bool) {
+	const op = "v1.mwr.Permission.scope"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	categoryIDs, isGlobal, err := p.userSrvc.PermissionCategoryIDs(r.Context(), user, permissionSlug.String())
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	if isGlobal {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	if isRequired && len(categoryIDs) == 0 {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, def.ErrAccessDenied))
+		return
+	}
+
+	scopeIDs, ok := request.GetCategoryScope(r)
+	if ok {
+		allowed := make(map[string]bool, len(categoryIDs))
+		for _, categoryID := range categoryIDs {
+			allowed[categoryID] = true
+		}
+
+		categoryIDs = []string{}
+		for _, scopeID := range scopeIDs {
+			if allowed[scopeID] {
+				categoryIDs = append(categoryIDs, scopeID)
+			}
+		}
+	}
+
+	ctx := context.WithValue(r.Context(), def.ContextCategoryScope, categoryIDs)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func (p *Permission) check(next http.HandlerFunc, permissionSlug def.PermissionSlug, scope ScopeFunc) http.HandlerFunc {
+	const op = "v1.mwr.Permission.check"
 	return func(w http.ResponseWriter, r *http.Request) {
 		user, err := request.GetAuthUser(r)
 		if err != nil {
@@ -27,7 +135,16 @@ func (p *Permission) MwrFunc(next http.HandlerFunc, permissionSlug string) http.
 			return
 		}
 
-		has, err := p.userSrvc.HasPermission(r.Context(), user, permissionSlug)
+		categoryID := ""
+		if scope != nil {
+			categoryID, err = scope(r)
+			if err != nil {
+				response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+				return
+			}
+		}
+
+		has, err := p.userSrvc.HasPermission(r.Context(), user, permissionSlug.String(), categoryID)
 		if err != nil {
 			response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 			return
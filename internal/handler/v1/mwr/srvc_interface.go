@@ -13,6 +13,7 @@ type (
 
 	UserSrvc interface {
 		GetByID(ctx context.Context, id string) (*model.User, error)
-		HasPermission(ctx context.Context, user *model.User, permissionSlug string) (bool, error)
+		HasPermission(ctx context.Context, user *model.User, permissionSlug, categoryID string) (bool, error)
+		PermissionCategoryIDs(ctx context.Context, user *model.User, permissionSlug string) ([]string, bool, error)
 	}
 )
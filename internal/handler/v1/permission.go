@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"tech_check/internal/def"
 	"tech_check/internal/handler/v1/mwr"
 	"tech_check/internal/handler/v1/request"
 	"tech_check/internal/handler/v1/response"
@@ -24,12 +25,12 @@ func newPermission(
 
 	mux.HandleFunc(
 		Url(http.MethodGet, "/permissions"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(p.list, "permission-read")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(p.list, def.PermissionPermissionRead)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodGet, "/permissions/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(p.show, "permission-read")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(p.show, def.PermissionPermissionRead)),
 	)
 }
 
@@ -3,9 +3,12 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"tech_check/internal/def"
 	"tech_check/internal/handler/v1/mwr"
 	"tech_check/internal/handler/v1/request"
 	"tech_check/internal/handler/v1/response"
+	"tech_check/internal/model"
 )
 
 type question struct {
@@ -24,27 +27,32 @@ func newQuestion(
 
 	mux.HandleFunc(
 		Url(http.MethodGet, "/questions"),
-		authMwr.MwrFunc(q.list),
+		authMwr.MwrFunc(permissionMwr.ListMwrFunc(permissionMwr.FilterMwrFunc(q.list), def.PermissionQuestionRead)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodPost, "/questions"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(q.create, "question-create")),
+		authMwr.MwrFunc(permissionMwr.ScopedMwrFunc(q.create, def.PermissionQuestionCreate, q.bodyScope)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodGet, "/questions/{id}"),
-		authMwr.MwrFunc(q.show),
+		authMwr.MwrFunc(permissionMwr.ScopedMwrFunc(q.show, def.PermissionQuestionRead, q.questionScope)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodPatch, "/questions/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(q.update, "question-edit")),
+		authMwr.MwrFunc(permissionMwr.ScopedMwrFunc(q.update, def.PermissionQuestionEdit, q.questionScope)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodDelete, "/questions/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(q.delete, "question-delete")),
+		authMwr.MwrFunc(permissionMwr.ScopedMwrFunc(q.delete, def.PermissionQuestionDelete, q.questionScope)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodGet, "/questions/{id}/stats"),
+		authMwr.MwrFunc(permissionMwr.ScopedMwrFunc(q.stats, def.PermissionQuestionRead, q.questionScope)),
 	)
 }
 
@@ -58,12 +66,18 @@ func newQuestion(
 // @Param sorts[updated_at] query string false "updated_at" Enums(asc, desc)
 // @Param filters[text] query string false "text"
 // @Param filters[grade] query string false "grade" Enums(junior, middle, senior)
+// @Param filters[category_ids] query string false "comma separated category ids"
+// @Param filters[permission] query string false "only categories the user holds this permission for"
 // @Produce json
 // @Success 200 {object} response.list{data=[]model.Question,pagination=dto.Pagination}
 func (q *question) list(w http.ResponseWriter, r *http.Request) {
 	const op = "v1.question.list"
 
 	search := request.GetQuerySearch(r)
+	categoryIDs, ok := request.GetCategoryScope(r)
+	if ok {
+		search.Filters["category_ids"] = strings.Join(categoryIDs, ",")
+	}
 	questions, pagination, err := q.questionSrvc.List(
 		r.Context(),
 		search.Pagination.Page,
@@ -102,6 +116,10 @@ func (q *question) create(w http.ResponseWriter, r *http.Request) {
 		req.Text,
 		req.Grade,
 		req.CategoryID,
+		req.Type,
+		toQuestionOptions(req.Options),
+		toQuestionCode(req.Code),
+		toQuestionRubric(req.Rubric),
 	)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
@@ -152,7 +170,16 @@ func (q *question) update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	question, err := q.questionSrvc.Update(r.Context(), id, req.Text, req.Grade)
+	question, err := q.questionSrvc.Update(
+		r.Context(),
+		id,
+		req.Text,
+		req.Grade,
+		req.Type,
+		toQuestionOptions(req.Options),
+		toQuestionCode(req.Code),
+		toQuestionRubric(req.Rubric),
+	)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 		return
@@ -180,3 +207,93 @@ func (q *question) delete(w http.ResponseWriter, r *http.Request) {
 
 	response.JsonSuccess(w, r, http.StatusNoContent, nil)
 }
+
+// @Summary get question answer statistics
+// @Tags questions
+// @Security BearerAuth
+// @Router /v1/questions/{id}/stats [get]
+// @Param id path string true "question id"
+// @Produce json
+// @Success 200 {object} response.success{data=dto.QuestionStats}
+func (q *question) stats(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.question.stats"
+
+	id := r.PathValue("id")
+	stats, err := q.questionSrvc.GetStats(r.Context(), id)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, stats)
+}
+
+func toQuestionOptions(req []request.QuestionOption) []model.QuestionOption {
+	options := make([]model.QuestionOption, 0, len(req))
+	for _, option := range req {
+		options = append(options, model.QuestionOption{
+			ID:        option.ID,
+			Text:      option.Text,
+			IsCorrect: option.IsCorrect,
+		})
+	}
+
+	return options
+}
+
+func toQuestionCode(req request.QuestionCode) model.QuestionCode {
+	tests := make([]model.QuestionTest, 0, len(req.Tests))
+	for _, test := range req.Tests {
+		tests = append(tests, model.QuestionTest{
+			Name: test.Name,
+			Code: test.Code,
+		})
+	}
+
+	return model.QuestionCode{
+		Stub:  req.Stub,
+		Tests: tests,
+	}
+}
+
+func toQuestionRubric(req request.QuestionRubric) model.QuestionRubric {
+	criteria := make([]model.QuestionCriterion, 0, len(req.Criteria))
+	for _, criterion := range req.Criteria {
+		criteria = append(criteria, model.QuestionCriterion{
+			Name:   criterion.Name,
+			Weight: criterion.Weight,
+		})
+	}
+
+	keyPoints := req.KeyPoints
+	if keyPoints == nil {
+		keyPoints = []string{}
+	}
+
+	return model.QuestionRubric{
+		ReferenceAnswer: req.ReferenceAnswer,
+		KeyPoints:       keyPoints,
+		Criteria:        criteria,
+	}
+}
+
+func (q *question) questionScope(r *http.Request) (string, error) {
+	question, err := q.questionSrvc.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return "", err
+	}
+
+	return question.CategoryID.Hex(), nil
+}
+
+// bodyScope leaves a malformed body to the handler, the request is then
+// checked for a global grant only and fails validation later.
+func (q *question) bodyScope(r *http.Request) (string, error) {
+	var req request.QuestionCreate
+	err := request.PeekBody(r, &req)
+	if err != nil {
+		return "", nil
+	}
+
+	return req.CategoryID, nil
+}
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"tech_check/internal/handler/v1/mwr"
+	"tech_check/internal/handler/v1/request"
+	"tech_check/internal/handler/v1/response"
+)
+
+type registration struct {
+	registrationSrvc RegistrationSrvc
+}
+
+func newRegistration(
+	mux *http.ServeMux,
+	authMwr *mwr.Auth,
+	registrationSrvc RegistrationSrvc,
+) {
+	rg := registration{
+		registrationSrvc: registrationSrvc,
+	}
+
+	mux.HandleFunc(Url(http.MethodPost, "/auth/register"), rg.register)
+	mux.HandleFunc(Url(http.MethodPost, "/auth/verify"), rg.verify)
+	mux.HandleFunc(Url(http.MethodPost, "/auth/verify/resend"), authMwr.MwrFunc(rg.resend))
+}
+
+// @Summary self registration
+// @Tags auth
+// @Router /v1/auth/register [post]
+// @Accept json
+// @Param body body request.Register true "register request"
+// @Produce json
+// @Success 201 {object} response.success{data=model.User}
+func (rg *registration) register(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.registration.register"
+
+	var req request.Register
+	err := request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	user, err := rg.registrationSrvc.Register(r.Context(), req.Email, req.Name, req.Password)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusCreated, user)
+}
+
+// @Summary verify email
+// @Tags auth
+// @Router /v1/auth/verify [post]
+// @Accept json
+// @Param body body request.VerifyEmail true "verify email request"
+// @Success 204
+func (rg *registration) verify(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.registration.verify"
+
+	var req request.VerifyEmail
+	err := request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	err = rg.registrationSrvc.Verify(r.Context(), req.Token)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
+
+// @Summary resend verification email
+// @Tags auth
+// @Security BearerAuth
+// @Router /v1/auth/verify/resend [post]
+// @Success 204
+func (rg *registration) resend(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.registration.resend"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	err = rg.registrationSrvc.ResendVerification(r.Context(), user)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
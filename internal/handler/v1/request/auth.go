@@ -10,8 +10,54 @@ type (
 		TokenID string `json:"token_id" validate:"required"`
 	}
 
+	OIDCLogin struct {
+		IDToken string `json:"id_token" validate:"required"`
+	}
+
 	Refresh struct {
 		AToken string `json:"access_token" validate:"required"`
 		RToken string `json:"refresh_token" validate:"required"`
 	}
+
+	Register struct {
+		Email    string `json:"email" validate:"required,email,max=50"`
+		Name     string `json:"name" validate:"required,max=50"`
+		Password string `json:"password" validate:"required,min=8,max=50"`
+	}
+
+	VerifyEmail struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	LoginChallenge struct {
+		ChallengeToken string `json:"challenge_token" validate:"required"`
+		Code           string `json:"code" validate:"required,max=20"`
+	}
+
+	TwoFactorCode struct {
+		Code string `json:"code" validate:"required,max=20"`
+	}
+
+	TwoFactorEnroll struct {
+		Password string `json:"password" validate:"max=50"`
+	}
+
+	TwoFactorConfirm struct {
+		Password string `json:"password" validate:"max=50"`
+		Code     string `json:"code" validate:"required,max=20"`
+	}
+
+	PasswordForgot struct {
+		Email string `json:"email" validate:"required,email,max=50"`
+	}
+
+	PasswordReset struct {
+		Token    string `json:"token" validate:"required"`
+		Password string `json:"password" validate:"required,min=8,max=50"`
+	}
+
+	PasswordChange struct {
+		CurrentPassword string `json:"current_password" validate:"required,max=50"`
+		NewPassword     string `json:"new_password" validate:"required,min=8,max=50"`
+	}
 )
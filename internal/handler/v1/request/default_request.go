@@ -2,6 +2,7 @@ package request
 
 import (
 	"net/http"
+	"tech_check/internal/dto"
 	"tech_check/internal/model"
 )
 
@@ -15,6 +16,10 @@ func ParseBody(r *http.Request, req interface{}) error {
 	return defaultParser.ParseBody(r, req)
 }
 
+func PeekBody(r *http.Request, req interface{}) error {
+	return defaultParser.PeekBody(r, req)
+}
+
 func GetQuerySearch(r *http.Request) *Search {
 	return defaultParser.GetQuerySearch(r)
 }
@@ -31,6 +36,18 @@ func GetHeaderIP(r *http.Request) string {
 	return defaultParser.GetHeaderIP(r)
 }
 
+func GetHeaderUserAgent(r *http.Request) string {
+	return defaultParser.GetHeaderUserAgent(r)
+}
+
 func GetAuthUser(r *http.Request) (*model.User, error) {
 	return defaultParser.GetAuthUser(r)
-}
\ No newline at end of file
+}
+
+func GetAuthClaims(r *http.Request) (*dto.Claims, error) {
+	return defaultParser.GetAuthClaims(r)
+}
+
+func GetCategoryScope(r *http.Request) ([]string, bool) {
+	return defaultParser.GetCategoryScope(r)
+}
@@ -2,13 +2,48 @@ package request
 
 type (
 	QuestionCreate struct {
-		Text       string `json:"text" validate:"required,min=3,max=200"`
-		Grade      string `json:"grade" validate:"required,oneof=junior middle senior"`
-		CategoryID string `json:"category_id" validate:"required,mongodb"`
+		Text       string           `json:"text" validate:"required,min=3,max=200"`
+		Grade      string           `json:"grade" validate:"required,oneof=junior middle senior"`
+		CategoryID string           `json:"category_id" validate:"required,mongodb"`
+		Type       string           `json:"type" validate:"omitempty,oneof=text single_choice multiple_choice code"`
+		Options    []QuestionOption `json:"options" validate:"max=10,dive"`
+		Code       QuestionCode     `json:"code"`
+		Rubric     QuestionRubric   `json:"rubric"`
 	}
 
 	QuestionUpdate struct {
-		Text  string `json:"text" validate:"required,min=3,max=200"`
-		Grade string `json:"grade" validate:"required,oneof=junior middle senior"`
+		Text    string           `json:"text" validate:"required,min=3,max=200"`
+		Grade   string           `json:"grade" validate:"required,oneof=junior middle senior"`
+		Type    string           `json:"type" validate:"omitempty,oneof=text single_choice multiple_choice code"`
+		Options []QuestionOption `json:"options" validate:"max=10,dive"`
+		Code    QuestionCode     `json:"code"`
+		Rubric  QuestionRubric   `json:"rubric"`
+	}
+
+	QuestionOption struct {
+		ID        string `json:"id" validate:"omitempty,mongodb"`
+		Text      string `json:"text" validate:"required,min=1,max=200"`
+		IsCorrect bool   `json:"is_correct"`
+	}
+
+	QuestionCode struct {
+		Stub  string         `json:"stub" validate:"max=5000"`
+		Tests []QuestionTest `json:"tests" validate:"max=50,dive"`
+	}
+
+	QuestionTest struct {
+		Name string `json:"name" validate:"required,min=1,max=100"`
+		Code string `json:"code" validate:"required,max=5000"`
+	}
+
+	QuestionRubric struct {
+		ReferenceAnswer string              `json:"reference_answer" validate:"max=2000"`
+		KeyPoints       []string            `json:"key_points" validate:"max=20,dive,required,max=200"`
+		Criteria        []QuestionCriterion `json:"criteria" validate:"max=10,dive"`
+	}
+
+	QuestionCriterion struct {
+		Name   string `json:"name" validate:"required,min=3,max=200"`
+		Weight int    `json:"weight" validate:"required,min=1,max=10"`
 	}
 )
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
@@ -10,11 +11,16 @@ import (
 	"strconv"
 	"strings"
 	"tech_check/internal/def"
+	"tech_check/internal/dto"
 	"tech_check/internal/model"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// maxBodySize bounds request bodies, so a huge body cannot exhaust memory
+// before it is rejected.
+const maxBodySize = 1 << 20
+
 type Parser struct {
 	validate *validator.Validate
 }
@@ -36,13 +42,17 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) ParseBody(r *http.Request, req interface{}) error {
-	decoder := json.NewDecoder(r.Body)
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize))
 
 	err := decoder.Decode(req)
 	if err != nil {
 		if errors.Is(err, io.EOF) {
 			return def.ErrInvalidBody
 		}
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return def.ErrBodyTooLarge
+		}
 		return err
 	}
 
@@ -54,6 +64,27 @@ func (p *Parser) ParseBody(r *http.Request, req interface{}) error {
 	return nil
 }
 
+// PeekBody decodes the body without consuming it, so it can still be
+// parsed by the handler. The value is not validated. A body over the limit
+// is handed on whole, so the handler's own parsing rejects it.
+func (p *Parser) PeekBody(r *http.Request, req interface{}) error {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return def.ErrBodyTooLarge
+		}
+		return err
+	}
+
+	if len(body) == 0 {
+		return def.ErrInvalidBody
+	}
+
+	return json.Unmarshal(body, req)
+}
+
 func (p *Parser) GetQuerySearch(r *http.Request) *Search {
 	return &Search{
 		Pagination: Pagination{
@@ -107,6 +138,10 @@ func (p *Parser) GetHeaderIP(r *http.Request) string {
 	return ip
 }
 
+func (p *Parser) GetHeaderUserAgent(r *http.Request) string {
+	return r.Header.Get(def.HeaderUserAgent.String())
+}
+
 func (p *Parser) GetAuthUser(r *http.Request) (*model.User, error) {
 	user, ok := r.Context().Value(def.ContextAuthUser).(*model.User)
 	if !ok {
@@ -115,3 +150,20 @@ func (p *Parser) GetAuthUser(r *http.Request) (*model.User, error) {
 
 	return user, nil
 }
+
+// GetCategoryScope returns the categories a list is limited to, ok is false
+// when the list is not limited.
+func (p *Parser) GetCategoryScope(r *http.Request) ([]string, bool) {
+	categoryIDs, ok := r.Context().Value(def.ContextCategoryScope).([]string)
+
+	return categoryIDs, ok
+}
+
+func (p *Parser) GetAuthClaims(r *http.Request) (*dto.Claims, error) {
+	claims, ok := r.Context().Value(def.ContextAuthClaims).(*dto.Claims)
+	if !ok {
+		return nil, def.ErrInvalidClaimsType
+	}
+
+	return claims, nil
+}
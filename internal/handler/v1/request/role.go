@@ -1,9 +1,11 @@
 package request
 
 type RoleCreate struct {
-	Name string `json:"name" validate:"required,min=5,max=50"`
+	Name        string `json:"name" validate:"required,min=5,max=50"`
+	RequireTOTP bool   `json:"require_totp"`
 }
 
 type RoleUpdate struct {
-	Name string `json:"name" validate:"required,min=5,max=50"`
+	Name        string `json:"name" validate:"required,min=5,max=50"`
+	RequireTOTP bool   `json:"require_totp"`
 }
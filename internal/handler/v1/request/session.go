@@ -2,7 +2,12 @@ package request
 
 type (
 	SessionCreate struct {
-		CategoryID string `json:"category_id" validate:"required,mongodb"`
-		Grade      string `json:"grade" validate:"required,oneof=junior middle senior"`
+		CategoryID string `json:"category_id" validate:"required_without=TemplateID,omitempty,mongodb"`
+		Grade      string `json:"grade" validate:"required_without=TemplateID,omitempty,oneof=junior middle senior"`
+		TemplateID string `json:"template_id" validate:"omitempty,mongodb"`
+		Mode       string `json:"mode" validate:"omitempty,oneof=fixed adaptive"`
+
+		TimeLimitMinute         int `json:"time_limit_minute" validate:"omitempty,min=1,max=480"`
+		QuestionTimeLimitSecond int `json:"question_time_limit_second" validate:"omitempty,min=10,max=3600"`
 	}
 )
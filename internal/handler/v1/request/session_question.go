@@ -1,5 +1,7 @@
 package request
 
 type SessionQuestionUpdate struct {
-	Answer string `json:"answer" validate:"required,min=1,max=500"`
+	Answer    string   `json:"answer" validate:"required_without_all=Code OptionIDs,omitempty,min=1,max=500"`
+	Code      string   `json:"code" validate:"required_without_all=Answer OptionIDs,omitempty,max=20000"`
+	OptionIDs []string `json:"option_ids" validate:"required_without_all=Answer Code,omitempty,max=10,unique,dive,mongodb"`
 }
@@ -0,0 +1,21 @@
+package request
+
+type (
+	SessionTemplateCreate struct {
+		Name        string          `json:"name" validate:"required,min=3,max=100"`
+		Description string          `json:"description" validate:"max=500"`
+		Buckets     []SessionBucket `json:"buckets" validate:"required,min=1,max=20,dive"`
+	}
+
+	SessionTemplateUpdate struct {
+		Name        string          `json:"name" validate:"required,min=3,max=100"`
+		Description string          `json:"description" validate:"max=500"`
+		Buckets     []SessionBucket `json:"buckets" validate:"required,min=1,max=20,dive"`
+	}
+
+	SessionBucket struct {
+		CategoryID string `json:"category_id" validate:"required,mongodb"`
+		Grade      string `json:"grade" validate:"required,oneof=junior middle senior"`
+		Count      int    `json:"count" validate:"required,min=1,max=50"`
+	}
+)
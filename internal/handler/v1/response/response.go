@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"tech_check/internal/def"
 
 	"github.com/go-playground/validator/v10"
@@ -57,6 +58,11 @@ func (b *Builder) JsonFail(w http.ResponseWriter, r *http.Request, err error) {
 		data = nil
 	}
 
+	var tooManyAttempts *def.TooManyAttemptsError
+	if errors.As(err, &tooManyAttempts) {
+		w.Header().Set(def.HeaderRetryAfter.String(), strconv.Itoa(tooManyAttempts.RetryAfterSeconds()))
+	}
+
 	f := fail{Message: msg, Data: data}
 
 	b.logFail(r, code, err)
@@ -96,27 +102,62 @@ func (b *Builder) Json(w http.ResponseWriter, code int, body interface{}) {
 func (b *Builder) getCode(err error) int {
 	code := http.StatusInternalServerError
 
-	if errors.Is(err, def.ErrNotFound) {
+	if errors.Is(err, def.ErrNotFound) ||
+		errors.Is(err, def.ErrUnknownProvider) {
 		code = http.StatusNotFound
 	} else if errors.Is(err, def.ErrAlreadyExists) ||
 		errors.Is(err, def.ErrInvalidBody) ||
 		errors.Is(err, def.ErrUserHasActiveSession) ||
 		errors.Is(err, def.ErrQuestionNotEnough) ||
-		errors.Is(err, def.ErrSessionFinished) {
+		errors.Is(err, def.ErrSessionFinished) ||
+		errors.Is(err, def.ErrSessionExpired) ||
+		errors.Is(err, def.ErrQuestionExpired) ||
+		errors.Is(err, def.ErrQuestionNotOpened) ||
+		errors.Is(err, def.ErrInvalidSessionMode) ||
+		errors.Is(err, def.ErrSessionNotAdaptive) ||
+		errors.Is(err, def.ErrSessionQuestionsOver) ||
+		errors.Is(err, def.ErrInvalidQuestionType) ||
+		errors.Is(err, def.ErrInvalidOptions) ||
+		errors.Is(err, def.ErrInvalidAnswer) ||
+		errors.Is(err, def.ErrUnknownOption) ||
+		errors.Is(err, def.ErrInvalidCode) ||
+		errors.Is(err, def.ErrInvalidResetToken) ||
+		errors.Is(err, def.ErrEmailDomainDenied) ||
+		errors.Is(err, def.ErrInvalidVerifyToken) ||
+		errors.Is(err, def.ErrEmailAlreadyVerified) ||
+		errors.Is(err, def.ErrTwoFactorEnabled) ||
+		errors.Is(err, def.ErrTwoFactorNotEnrolled) ||
+		errors.Is(err, def.ErrRoleCycle) {
 		code = http.StatusBadRequest
+	} else if errors.Is(err, def.ErrEvaluationPending) ||
+		errors.Is(err, def.ErrQuestionAnswered) {
+		code = http.StatusConflict
+	} else if errors.Is(err, def.ErrBodyTooLarge) {
+		code = http.StatusRequestEntityTooLarge
+	} else if errors.Is(err, def.ErrTooManyAttempts) {
+		code = http.StatusTooManyRequests
 	} else if errors.Is(err, def.ErrInvalidCredentials) ||
 		errors.Is(err, def.ErrAuthMissing) ||
 		errors.Is(err, def.ErrInvalidAuthFormat) ||
 		errors.Is(err, def.ErrInvalidSigningMethod) ||
+		errors.Is(err, def.ErrUnknownSigningKey) ||
 		errors.Is(err, def.ErrInvalidClaimsType) ||
 		errors.Is(err, def.ErrATokenExpired) ||
+		errors.Is(err, def.ErrATokenRevoked) ||
 		errors.Is(err, def.ErrInvalidUserType) ||
 		errors.Is(err, def.ErrTokensMismatch) ||
 		errors.Is(err, def.ErrRTokenExpired) ||
-		errors.Is(err, def.ErrInvalidRToken) {
+		errors.Is(err, def.ErrInvalidRToken) ||
+		errors.Is(err, def.ErrRTokenReused) ||
+		errors.Is(err, def.ErrInvalidTwoFactorCode) ||
+		errors.Is(err, def.ErrInvalidChallenge) ||
+		errors.Is(err, def.ErrInvalidIDToken) {
 		code = http.StatusUnauthorized
 	} else if errors.Is(err, def.ErrCannotLogin) ||
-		errors.Is(err, def.ErrAccessDenied) {
+		errors.Is(err, def.ErrAccessDenied) ||
+		errors.Is(err, def.ErrRegistrationDisabled) ||
+		errors.Is(err, def.ErrEmailNotVerified) ||
+		errors.Is(err, def.ErrTwoFactorRequired) {
 		code = http.StatusForbidden
 	}
 
@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"tech_check/internal/def"
 	"tech_check/internal/handler/v1/mwr"
 	"tech_check/internal/handler/v1/request"
 	"tech_check/internal/handler/v1/response"
@@ -24,37 +25,52 @@ func newRole(
 
 	mux.HandleFunc(
 		Url(http.MethodGet, "/roles"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(re.list, "role-read")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.list, def.PermissionRoleRead)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodPost, "/roles"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(re.create, "role-create")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.create, def.PermissionRoleCreate)),
 	)
 	
 	mux.HandleFunc(
 		Url(http.MethodGet, "/roles/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(re.show, "role-read")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.show, def.PermissionRoleRead)),
 	)
 	
 	mux.HandleFunc(
 		Url(http.MethodPatch, "/roles/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(re.update, "role-edit")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.update, def.PermissionRoleEdit)),
 	)
 	
 	mux.HandleFunc(
 		Url(http.MethodDelete, "/roles/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(re.delete, "role-delete")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.delete, def.PermissionRoleDelete)),
 	)
 	
 	mux.HandleFunc(
 		Url(http.MethodPost, "/roles/{id}/permissions/{permissionID}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(re.addPermission, "role-edit")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.addPermission, def.PermissionRoleEdit)),
 	)
 	
 	mux.HandleFunc(
 		Url(http.MethodDelete, "/roles/{id}/permissions/{permissionID}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(re.removePermission, "role-edit")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.removePermission, def.PermissionRoleEdit)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodGet, "/roles/{id}/permissions"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.expandPermissions, def.PermissionRoleRead)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodPost, "/roles/{id}/parents/{parentID}"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.addParent, def.PermissionRoleEdit)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodDelete, "/roles/{id}/parents/{parentID}"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(re.removeParent, def.PermissionRoleEdit)),
 	)
 }
 
@@ -109,7 +125,7 @@ func (re *role) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	role, err := re.roleSrvc.Create(r.Context(), req.Name)
+	role, err := re.roleSrvc.Create(r.Context(), req.Name, req.RequireTOTP)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 		return
@@ -159,7 +175,7 @@ func (re *role) update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	role, err := re.roleSrvc.Update(r.Context(), id, req.Name)
+	role, err := re.roleSrvc.Update(r.Context(), id, req.Name, req.RequireTOTP)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 		return
@@ -228,3 +244,64 @@ func (re *role) removePermission(w http.ResponseWriter, r *http.Request) {
 
 	response.JsonSuccess(w, r, http.StatusOK, role)
 }
+
+// @Summary role permissions expanded through parent roles and wildcards
+// @Tags roles
+// @Security BearerAuth
+// @Router /v1/roles/{id}/permissions [get]
+// @Param id path string true "role id"
+// @Success 200 {object} response.success{data=dto.RolePermissions}
+func (re *role) expandPermissions(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.role.expandPermissions"
+
+	id := r.PathValue("id")
+	permissions, err := re.roleSrvc.ExpandPermissions(r.Context(), id)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, permissions)
+}
+
+// @Summary add parent role
+// @Tags roles
+// @Security BearerAuth
+// @Router /v1/roles/{id}/parents/{parentID} [post]
+// @Param id path string true "role id"
+// @Param parentID path string true "parent role id"
+// @Success 200 {object} response.success{data=model.Role}
+func (re *role) addParent(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.role.addParent"
+
+	id := r.PathValue("id")
+	parentID := r.PathValue("parentID")
+	role, err := re.roleSrvc.AddParent(r.Context(), id, parentID)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, role)
+}
+
+// @Summary remove parent role
+// @Tags roles
+// @Security BearerAuth
+// @Router /v1/roles/{id}/parents/{parentID} [delete]
+// @Param id path string true "role id"
+// @Param parentID path string true "parent role id"
+// @Success 200 {object} response.success{data=model.Role}
+func (re *role) removeParent(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.role.removeParent"
+
+	id := r.PathValue("id")
+	parentID := r.PathValue("parentID")
+	role, err := re.roleSrvc.RemoveParent(r.Context(), id, parentID)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, role)
+}
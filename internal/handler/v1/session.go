@@ -31,6 +31,11 @@ func newSession(
 		authMwr.MwrFunc(s.create),
 	)
 
+	mux.HandleFunc(
+		Url(http.MethodPost, "/sessions/{id}/next"),
+		authMwr.MwrFunc(s.next),
+	)
+
 	mux.HandleFunc(
 		Url(http.MethodPost, "/sessions/{id}/summarize"),
 		authMwr.MwrFunc(s.summarize),
@@ -102,6 +107,10 @@ func (s *session) create(w http.ResponseWriter, r *http.Request) {
 		user,
 		req.CategoryID,
 		req.Grade,
+		req.TemplateID,
+		req.Mode,
+		req.TimeLimitMinute,
+		req.QuestionTimeLimitSecond,
 	)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
@@ -111,6 +120,32 @@ func (s *session) create(w http.ResponseWriter, r *http.Request) {
 	response.JsonSuccess(w, r, http.StatusCreated, session)
 }
 
+// @Summary get next question of adaptive session
+// @Tags sessions
+// @Security BearerAuth
+// @Router /v1/sessions/{id}/next [post]
+// @Param id path string true "session id"
+// @Produce json
+// @Success 200 {object} response.success{data=model.SessionQuestion}
+func (s *session) next(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.session.next"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	id := r.PathValue("id")
+	question, err := s.sessionSrvc.Next(r.Context(), user, id)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, question)
+}
+
 // @Summary finish the session with summary
 // @Tags sessions
 // @Security BearerAuth
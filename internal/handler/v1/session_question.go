@@ -63,9 +63,10 @@ func (s *sessionQuestion) list(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	questions, err := s.sessionQuestionSrvc.List(r.Context(), session)
+	questions, err := s.sessionQuestionSrvc.Preview(r.Context(), session)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
 	}
 
 	response.JsonSuccess(w, r, http.StatusOK, questions)
@@ -96,7 +97,7 @@ func (s *sessionQuestion) show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id := r.PathValue("id")
-	question, err := s.sessionQuestionSrvc.GetByID(r.Context(), session, id)
+	question, err := s.sessionQuestionSrvc.Open(r.Context(), session, id)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 		return
@@ -144,6 +145,8 @@ func (s *sessionQuestion) update(w http.ResponseWriter, r *http.Request) {
 		session,
 		id,
 		req.Answer,
+		req.Code,
+		req.OptionIDs,
 	)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
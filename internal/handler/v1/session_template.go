@@ -0,0 +1,204 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/handler/v1/mwr"
+	"tech_check/internal/handler/v1/request"
+	"tech_check/internal/handler/v1/response"
+)
+
+type sessionTemplate struct {
+	sessionTemplateSrvc SessionTemplateSrvc
+}
+
+func newSessionTemplate(
+	mux *http.ServeMux,
+	authMwr *mwr.Auth,
+	permissionMwr *mwr.Permission,
+	sessionTemplateSrvc SessionTemplateSrvc,
+) {
+	s := sessionTemplate{
+		sessionTemplateSrvc: sessionTemplateSrvc,
+	}
+
+	mux.HandleFunc(
+		Url(http.MethodGet, "/session-templates"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(s.list, def.PermissionSessionTemplateRead)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodPost, "/session-templates"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(s.create, def.PermissionSessionTemplateCreate)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodGet, "/session-templates/{id}"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(s.show, def.PermissionSessionTemplateRead)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodPatch, "/session-templates/{id}"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(s.update, def.PermissionSessionTemplateEdit)),
+	)
+
+	mux.HandleFunc(
+		Url(http.MethodDelete, "/session-templates/{id}"),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(s.delete, def.PermissionSessionTemplateDelete)),
+	)
+}
+
+// @Summary session templates list
+// @Tags sessionTemplates
+// @Security BearerAuth
+// @Router /v1/session-templates [get]
+// @Param pagination[page] query int false "page"
+// @Param pagination[count] query int false "count"
+// @Param sorts[created_at] query string false "created_at" Enums(asc, desc)
+// @Param sorts[updated_at] query string false "updated_at" Enums(asc, desc)
+// @Param sorts[name] query string false "name" Enums(asc, desc)
+// @Param filters[name] query string false "name"
+// @Param filters[description] query string false "description"
+// @Produce json
+// @Success 200 {object} response.list{data=[]model.SessionTemplate,pagination=dto.Pagination}
+func (s *sessionTemplate) list(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.sessionTemplate.list"
+
+	search := request.GetQuerySearch(r)
+	templates, pagination, err := s.sessionTemplateSrvc.List(
+		r.Context(),
+		search.Pagination.Page,
+		search.Pagination.Count,
+		search.Filters,
+		search.Sorts,
+	)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonList(w, r, templates, pagination)
+}
+
+// @Summary create session template
+// @Tags sessionTemplates
+// @Security BearerAuth
+// @Router /v1/session-templates [post]
+// @Accept json
+// @Param body body request.SessionTemplateCreate true "session template create request"
+// @Produce json
+// @Success 201 {object} response.success{data=model.SessionTemplate}
+func (s *sessionTemplate) create(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.sessionTemplate.create"
+
+	var req request.SessionTemplateCreate
+	err := request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	template, err := s.sessionTemplateSrvc.Create(
+		r.Context(),
+		req.Name,
+		req.Description,
+		toSessionBuckets(req.Buckets),
+	)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusCreated, template)
+}
+
+// @Summary get session template by id
+// @Tags sessionTemplates
+// @Security BearerAuth
+// @Router /v1/session-templates/{id} [get]
+// @Param id path string true "session template id"
+// @Produce json
+// @Success 200 {object} response.success{data=model.SessionTemplate}
+func (s *sessionTemplate) show(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.sessionTemplate.show"
+
+	id := r.PathValue("id")
+	template, err := s.sessionTemplateSrvc.GetByID(r.Context(), id)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, template)
+}
+
+// @Summary update session template
+// @Tags sessionTemplates
+// @Security BearerAuth
+// @Router /v1/session-templates/{id} [patch]
+// @Accept json
+// @Param id path string true "session template id"
+// @Param body body request.SessionTemplateUpdate true "session template update request"
+// @Produce json
+// @Success 200 {object} response.success{data=model.SessionTemplate}
+func (s *sessionTemplate) update(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.sessionTemplate.update"
+
+	id := r.PathValue("id")
+	var req request.SessionTemplateUpdate
+
+	err := request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	template, err := s.sessionTemplateSrvc.Update(
+		r.Context(),
+		id,
+		req.Name,
+		req.Description,
+		toSessionBuckets(req.Buckets),
+	)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, template)
+}
+
+// @Summary delete session template by id
+// @Tags sessionTemplates
+// @Security BearerAuth
+// @Router /v1/session-templates/{id} [delete]
+// @Param id path string true "session template id"
+// @Success 204
+func (s *sessionTemplate) delete(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.sessionTemplate.delete"
+
+	id := r.PathValue("id")
+
+	err := s.sessionTemplateSrvc.Delete(r.Context(), id)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
+
+func toSessionBuckets(req []request.SessionBucket) []dto.SessionBucket {
+	buckets := make([]dto.SessionBucket, 0, len(req))
+	for _, bucket := range req {
+		buckets = append(buckets, dto.SessionBucket{
+			CategoryID: bucket.CategoryID,
+			Grade:      bucket.Grade,
+			Count:      bucket.Count,
+		})
+	}
+
+	return buckets
+}
@@ -13,25 +13,61 @@ type (
 		GetByID(ctx context.Context, id string) (*model.User, error)
 		Update(ctx context.Context, id, name string) (*model.User, error)
 		Delete(ctx context.Context, id string) error
-		AddRole(ctx context.Context, id, roleID string) (*model.User, error)
-		RemoveRole(ctx context.Context, id, roleID string) (*model.User, error)
+		AddRole(ctx context.Context, id, roleID, categoryID string) (*model.User, error)
+		RemoveRole(ctx context.Context, id, roleID, categoryID string) (*model.User, error)
+	}
+
+	RegistrationSrvc interface {
+		Register(ctx context.Context, email, name, password string) (*model.User, error)
+		Verify(ctx context.Context, token string) error
+		ResendVerification(ctx context.Context, user *model.User) error
+	}
+
+	TwoFactorSrvc interface {
+		Enroll(ctx context.Context, user *model.User, password, ip string) (*dto.TwoFactorEnrollment, error)
+		Confirm(ctx context.Context, user *model.User, password, code, ip string) (*dto.RecoveryCodes, error)
+		Disable(ctx context.Context, user *model.User, code string) error
 	}
 
 	AuthSrvc interface {
-		Login(ctx context.Context, email, password, ip string) (*dto.Token, error)
-		GoogleLogin(ctx context.Context, tokenID, ip string) (*dto.Token, error)
+		Login(ctx context.Context, email, password, ip, userAgent string) (*dto.Token, *dto.Challenge, error)
+		GoogleLogin(ctx context.Context, tokenID, ip, userAgent string) (*dto.Token, *dto.Challenge, error)
+		OIDCLogin(ctx context.Context, provider, idToken, ip, userAgent string) (*dto.Token, *dto.Challenge, error)
+		VerifyChallenge(ctx context.Context, challengeToken, code, ip, userAgent string) (*dto.Token, error)
 		DecodeAToken(ctx context.Context, aToken string) (*dto.Claims, error)
-		Refresh(ctx context.Context, aToken, rToken, ip string) (*dto.Token, error)
+		Refresh(ctx context.Context, aToken, rToken, ip, userAgent string) (*dto.Token, error)
+		Me(ctx context.Context, user *model.User) (*dto.Me, error)
+		JWKS() *dto.JWKS
+		Logout(ctx context.Context, user *model.User, refreshTokenID string) error
+		LogoutAll(ctx context.Context, user *model.User) error
+		ForgotPassword(ctx context.Context, email, ip string) error
+		ResetPassword(ctx context.Context, token, password, ip string) error
+		ChangePassword(ctx context.Context, user *model.User, currentPassword, newPassword, ip string) error
+		Devices(ctx context.Context, user *model.User, refreshTokenID string) ([]dto.Device, error)
+		RevokeDevice(ctx context.Context, user *model.User, id string) error
 	}
 
 	RoleSrvc interface {
 		List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.Role, *dto.Pagination, error)
-		Create(ctx context.Context, name string) (*model.Role, error)
+		Create(ctx context.Context, name string, requireTOTP bool) (*model.Role, error)
 		GetByID(ctx context.Context, id string) (*model.Role, error)
 		Delete(ctx context.Context, id string) error
 		AddPermission(ctx context.Context, id, permissionID string) (*model.Role, error)
 		RemovePermission(ctx context.Context, id, permissionID string) (*model.Role, error)
-		Update(ctx context.Context, id, name string) (*model.Role, error)
+		Update(ctx context.Context, id, name string, requireTOTP bool) (*model.Role, error)
+		AddParent(ctx context.Context, id, parentID string) (*model.Role, error)
+		RemoveParent(ctx context.Context, id, parentID string) (*model.Role, error)
+		ExpandPermissions(ctx context.Context, id string) (*dto.RolePermissions, error)
+	}
+
+	LockoutSrvc interface {
+		List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.Lockout, *dto.Pagination, error)
+		GetByID(ctx context.Context, id string) (*model.Lockout, error)
+		Delete(ctx context.Context, id string) error
+	}
+
+	AuditLogSrvc interface {
+		List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.AuditLog, *dto.Pagination, error)
 	}
 
 	PermissionSrvc interface {
@@ -49,23 +85,55 @@ type (
 
 	QuestionSrvc interface {
 		List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.Question, *dto.Pagination, error)
-		Create(ctx context.Context, text, grade, categoryID string) (*model.Question, error)
+		Create(
+			ctx context.Context,
+			text, grade, categoryID, questionType string,
+			options []model.QuestionOption,
+			code model.QuestionCode,
+			rubric model.QuestionRubric,
+		) (*model.Question, error)
 		GetByID(ctx context.Context, id string) (*model.Question, error)
-		Update(ctx context.Context, id, grade, text string) (*model.Question, error)
+		Update(
+			ctx context.Context,
+			id, text, grade, questionType string,
+			options []model.QuestionOption,
+			code model.QuestionCode,
+			rubric model.QuestionRubric,
+		) (*model.Question, error)
+		Delete(ctx context.Context, id string) error
+		GetStats(ctx context.Context, id string) (*dto.QuestionStats, error)
+	}
+
+	SessionTemplateSrvc interface {
+		List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.SessionTemplate, *dto.Pagination, error)
+		Create(ctx context.Context, name, description string, buckets []dto.SessionBucket) (*model.SessionTemplate, error)
+		GetByID(ctx context.Context, id string) (*model.SessionTemplate, error)
+		Update(ctx context.Context, id, name, description string, buckets []dto.SessionBucket) (*model.SessionTemplate, error)
 		Delete(ctx context.Context, id string) error
 	}
 
 	SessionSrvc interface {
 		List(ctx context.Context, user *model.User, page, count int) ([]model.Session, *dto.Pagination, error)
-		Create(ctx context.Context, user *model.User, categoryID, grade string) (*model.Session, error)
+		Create(
+			ctx context.Context,
+			user *model.User,
+			categoryID, grade, templateID, mode string,
+			timeLimitMinute, questionTimeLimitSecond int,
+		) (*model.Session, error)
 		GetByID(ctx context.Context, user *model.User, id string) (*model.Session, error)
+		Next(ctx context.Context, user *model.User, id string) (*model.SessionQuestion, error)
 		Summarize(ctx context.Context, user *model.User, id string) (*model.Session, error)
 		Cancel(ctx context.Context, user *model.User, id string) (*model.Session, error)
 	}
 
 	SessionQuestionSrvc interface {
-		List(ctx context.Context, session *model.Session) ([]model.SessionQuestion, error)
-		GetByID(ctx context.Context, session *model.Session, id string) (*model.SessionQuestion, error)
-		Update(ctx context.Context, session *model.Session, id, answer string) (*model.SessionQuestion, error)
+		Preview(ctx context.Context, session *model.Session) ([]model.SessionQuestion, error)
+		Open(ctx context.Context, session *model.Session, id string) (*model.SessionQuestion, error)
+		Update(
+			ctx context.Context,
+			session *model.Session,
+			id, answer, code string,
+			optionIDs []string,
+		) (*model.SessionQuestion, error)
 	}
 )
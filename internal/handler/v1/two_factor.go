@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"tech_check/internal/handler/v1/mwr"
+	"tech_check/internal/handler/v1/request"
+	"tech_check/internal/handler/v1/response"
+)
+
+type twoFactor struct {
+	twoFactorSrvc TwoFactorSrvc
+}
+
+func newTwoFactor(
+	mux *http.ServeMux,
+	authMwr *mwr.Auth,
+	twoFactorSrvc TwoFactorSrvc,
+) {
+	t := twoFactor{
+		twoFactorSrvc: twoFactorSrvc,
+	}
+
+	mux.HandleFunc(Url(http.MethodPost, "/auth/2fa/enroll"), authMwr.MwrFunc(t.enroll))
+	mux.HandleFunc(Url(http.MethodPost, "/auth/2fa/confirm"), authMwr.MwrFunc(t.confirm))
+	mux.HandleFunc(Url(http.MethodPost, "/auth/2fa/disable"), authMwr.MwrFunc(t.disable))
+}
+
+// @Summary start two-factor enrollment
+// @Tags auth
+// @Security BearerAuth
+// @Router /v1/auth/2fa/enroll [post]
+// @Accept json
+// @Param body body request.TwoFactorEnroll true "two-factor enroll request"
+// @Produce json
+// @Success 200 {object} response.success{data=dto.TwoFactorEnrollment}
+func (t *twoFactor) enroll(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.twoFactor.enroll"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	var req request.TwoFactorEnroll
+	err = request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	enrollment, err := t.twoFactorSrvc.Enroll(r.Context(), user, req.Password, request.GetHeaderIP(r))
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, enrollment)
+}
+
+// @Summary confirm two-factor enrollment
+// @Tags auth
+// @Security BearerAuth
+// @Router /v1/auth/2fa/confirm [post]
+// @Accept json
+// @Param body body request.TwoFactorConfirm true "two-factor confirm request"
+// @Produce json
+// @Success 200 {object} response.success{data=dto.RecoveryCodes}
+func (t *twoFactor) confirm(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.twoFactor.confirm"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	var req request.TwoFactorConfirm
+	err = request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	recoveryCodes, err := t.twoFactorSrvc.Confirm(r.Context(), user, req.Password, req.Code, request.GetHeaderIP(r))
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusOK, recoveryCodes)
+}
+
+// @Summary disable two-factor authentication
+// @Tags auth
+// @Security BearerAuth
+// @Router /v1/auth/2fa/disable [post]
+// @Accept json
+// @Param body body request.TwoFactorCode true "two-factor code request"
+// @Success 204
+func (t *twoFactor) disable(w http.ResponseWriter, r *http.Request) {
+	const op = "v1.twoFactor.disable"
+
+	user, err := request.GetAuthUser(r)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	var req request.TwoFactorCode
+	err = request.ParseBody(r, &req)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	err = t.twoFactorSrvc.Disable(r.Context(), user, req.Code)
+	if err != nil {
+		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	response.JsonSuccess(w, r, http.StatusNoContent, nil)
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"tech_check/internal/def"
 	"tech_check/internal/handler/v1/mwr"
 	"tech_check/internal/handler/v1/request"
 	"tech_check/internal/handler/v1/response"
@@ -24,7 +25,7 @@ func newUser(
 
 	mux.HandleFunc(
 		Url(http.MethodGet, "/users"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(u.list, "user-read")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(u.list, def.PermissionUserRead)),
 	)
 
 	mux.HandleFunc(
@@ -34,27 +35,27 @@ func newUser(
 
 	mux.HandleFunc(
 		Url(http.MethodGet, "/users/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(u.show, "user-read")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(u.show, def.PermissionUserRead)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodPatch, "/users/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(u.update, "user-edit")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(u.update, def.PermissionUserEdit)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodDelete, "/users/{id}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(u.delete, "user-delete")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(u.delete, def.PermissionUserDelete)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodPost, "/users/{id}/roles/{roleID}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(u.addRole, "user-edit")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(u.addRole, def.PermissionUserEdit)),
 	)
 
 	mux.HandleFunc(
 		Url(http.MethodDelete, "/users/{id}/roles/{roleID}"),
-		authMwr.MwrFunc(permissionMwr.MwrFunc(u.removeRole, "user-edit")),
+		authMwr.MwrFunc(permissionMwr.MwrFunc(u.removeRole, def.PermissionUserEdit)),
 	)
 }
 
@@ -196,13 +197,15 @@ func (u *user) delete(w http.ResponseWriter, r *http.Request) {
 // @Router /v1/users/{id}/roles/{roleID} [post]
 // @Param id path string true "user id"
 // @Param roleID path string true "role id"
+// @Param category_id query string false "grant the role only for this category"
 // @Success 200 {object} response.success{data=model.User}
 func (u *user) addRole(w http.ResponseWriter, r *http.Request) {
 	const op = "v1.user.addRole"
 
 	id := r.PathValue("id")
 	roleID := r.PathValue("roleID")
-	user, err := u.userSrvc.AddRole(r.Context(), id, roleID)
+	categoryID := r.URL.Query().Get("category_id")
+	user, err := u.userSrvc.AddRole(r.Context(), id, roleID, categoryID)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 		return
@@ -217,13 +220,15 @@ func (u *user) addRole(w http.ResponseWriter, r *http.Request) {
 // @Router /v1/users/{id}/roles/{roleID} [delete]
 // @Param id path string true "user id"
 // @Param roleID path string true "role id"
+// @Param category_id query string false "remove the grant scoped to this category"
 // @Success 200 {object} response.success{data=model.User}
 func (u *user) removeRole(w http.ResponseWriter, r *http.Request) {
 	const op = "v1.user.removeRole"
 
 	id := r.PathValue("id")
 	roleID := r.PathValue("roleID")
-	user, err := u.userSrvc.RemoveRole(r.Context(), id, roleID)
+	categoryID := r.URL.Query().Get("category_id")
+	user, err := u.userSrvc.RemoveRole(r.Context(), id, roleID, categoryID)
 	if err != nil {
 		response.JsonFail(w, r, fmt.Errorf("%s: %w", op, err))
 		return
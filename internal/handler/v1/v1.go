@@ -19,10 +19,15 @@ func New(mux *http.ServeMux, app *app.App) http.Handler {
 
 	newUser(mux, authMwr, permissionMwr, app.Srvcs.User)
 	newAuth(mux, authMwr, app.Srvcs.Auth)
+	newRegistration(mux, authMwr, app.Srvcs.Registration)
+	newTwoFactor(mux, authMwr, app.Srvcs.TwoFactor)
 	newRole(mux, authMwr, permissionMwr, app.Srvcs.Role)
 	newPermission(mux, authMwr, permissionMwr, app.Srvcs.Permission)
+	newLockout(mux, authMwr, permissionMwr, app.Srvcs.Lockout)
+	newAuditLog(mux, authMwr, permissionMwr, app.Srvcs.AuditLog)
 	newCategory(mux, authMwr, permissionMwr, app.Srvcs.Category)
 	newQuestion(mux, authMwr, permissionMwr, app.Srvcs.Question)
+	newSessionTemplate(mux, authMwr, permissionMwr, app.Srvcs.SessionTemplate)
 	newSession(mux, authMwr, app.Srvcs.Session)
 	newSessionQuestion(mux, authMwr, app.Srvcs.Session, app.Srvcs.SessionQuestion)
 
@@ -0,0 +1,51 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"tech_check/internal/dto"
+	"time"
+)
+
+// File writes every mail as an .eml file into dir instead of sending it,
+// which is enough to inspect mails in development.
+type File struct {
+	dir  string
+	from string
+	lg   *slog.Logger
+}
+
+func NewFile(dir, from string, lg *slog.Logger) *File {
+	return &File{
+		dir:  dir,
+		from: from,
+		lg:   lg,
+	}
+}
+
+func (f *File) Send(ctx context.Context, mail *dto.Mail) error {
+	const op = "mailer.File.Send"
+
+	message, err := buildMessage(f.from, mail)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = os.MkdirAll(f.dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	path := filepath.Join(f.dir, fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), mail.To))
+	err = os.WriteFile(path, message, 0o644)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	f.lg.Info("mail captured", slog.String("to", mail.To), slog.String("subject", mail.Subject), slog.String("path", path))
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"tech_check/internal/dto"
+	"time"
+)
+
+type SMTP struct {
+	addr    string
+	host    string
+	auth    smtp.Auth
+	from    string
+	sender  string
+	timeout time.Duration
+}
+
+// NewSMTP keeps from as is for the From: header and uses its bare address
+// as the envelope sender, which servers reject with a display name.
+func NewSMTP(host string, port int, username, password, from string, timeout time.Duration) (*SMTP, error) {
+	const op = "mailer.NewSMTP"
+
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTP{
+		addr:    net.JoinHostPort(host, strconv.Itoa(port)),
+		host:    host,
+		auth:    auth,
+		from:    from,
+		sender:  sender.Address,
+		timeout: timeout,
+	}, nil
+}
+
+// Send does what smtp.SendMail does, but the whole conversation is bound
+// by the timeout and by ctx, so a stalled server cannot hold a worker.
+func (s *SMTP) Send(ctx context.Context, mail *dto.Mail) error {
+	const op = "mailer.SMTP.Send"
+
+	message, err := buildMessage(s.from, mail)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	err = s.send(conn, mail.To, message)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *SMTP) send(conn net.Conn, to string, message []byte) error {
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: s.host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.auth != nil {
+		err = client.Auth(s.auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(s.sender)
+	if err != nil {
+		return err
+	}
+	err = client.Rcpt(to)
+	if err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(message)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,74 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"text/template"
+)
+
+//go:embed template
+var templateFS embed.FS
+
+type Templates struct {
+	text map[def.MailTemplate]*template.Template
+	html map[def.MailTemplate]*htmltemplate.Template
+}
+
+// MustNewTemplates parses the text and HTML variant of every mail template.
+// The text variant also defines the subject.
+func MustNewTemplates() *Templates {
+	names := []def.MailTemplate{
+		def.MailNewDevice,
+		def.MailIPChanged,
+		def.MailPasswordChanged,
+		def.MailPasswordReset,
+		def.MailVerifyEmail,
+	}
+
+	t := Templates{
+		text: make(map[def.MailTemplate]*template.Template, len(names)),
+		html: make(map[def.MailTemplate]*htmltemplate.Template, len(names)),
+	}
+	for _, name := range names {
+		t.text[name] = template.Must(template.ParseFS(templateFS, "template/"+name.String()+".txt"))
+		t.html[name] = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "template/layout.html", "template/"+name.String()+".html"))
+	}
+
+	return &t
+}
+
+func (t *Templates) Render(name def.MailTemplate, to string, data map[string]string) (*dto.Mail, error) {
+	const op = "mailer.Templates.Render"
+
+	text, ok := t.text[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrUnknownMailTemplate)
+	}
+
+	var subject, textBody, htmlBody bytes.Buffer
+	err := text.ExecuteTemplate(&subject, "subject", data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = text.Execute(&textBody, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = t.html[name].ExecuteTemplate(&htmlBody, "layout", data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &dto.Mail{
+		To:      to,
+		Subject: subject.String(),
+		Text:    textBody.String(),
+		HTML:    htmlBody.String(),
+	}, nil
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"tech_check/internal/def"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditLog struct {
+	ID         primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	ActorID    *primitive.ObjectID    `bson:"actor_id" json:"actor_id"`
+	ActorEmail string                 `bson:"actor_email" json:"actor_email"`
+	RequestID  string                 `bson:"request_id" json:"request_id"`
+	Action     def.AuditAction        `bson:"action" json:"action"`
+	TargetType def.AuditTarget        `bson:"target_type" json:"target_type"`
+	TargetID   primitive.ObjectID     `bson:"target_id" json:"target_id"`
+	Changes    map[string]AuditChange `bson:"changes" json:"changes"`
+	CreatedAt  time.Time              `bson:"created_at" json:"created_at"`
+}
+
+// AuditChange holds the json value of one field before and after the
+// change, a nil side means the target was created or deleted.
+type AuditChange struct {
+	Before interface{} `bson:"before" json:"before"`
+	After  interface{} `bson:"after" json:"after"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"tech_check/internal/def"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Job struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Type        def.JobType        `bson:"type" json:"type"`
+	Payload     map[string]string  `bson:"payload" json:"payload"`
+	Status      def.JobStatus      `bson:"status" json:"status"`
+	Attempts    int                `bson:"attempts" json:"attempts"`
+	MaxAttempts int                `bson:"max_attempts" json:"max_attempts"`
+	LastError   string             `bson:"last_error" json:"last_error"`
+	RunAt       time.Time          `bson:"run_at" json:"run_at"`
+	LockedAt    *time.Time         `bson:"locked_at" json:"locked_at"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"tech_check/internal/def"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Lockout struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Kind        def.LockoutKind    `bson:"kind" json:"kind"`
+	Key         string             `bson:"key" json:"key"`
+	Failures    int                `bson:"failures" json:"failures"`
+	LockedUntil *time.Time         `bson:"locked_until" json:"locked_until"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
@@ -7,11 +7,44 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-type Question struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Text       string             `bson:"text" json:"text"`
-	Grade      def.GradeName      `bson:"grade" json:"grade"`
-	CategoryID primitive.ObjectID `bson:"category_id" json:"category_id"`
-	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
-}
+type (
+	Question struct {
+		ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+		Text       string             `bson:"text" json:"text"`
+		Grade      def.GradeName      `bson:"grade" json:"grade"`
+		CategoryID primitive.ObjectID `bson:"category_id" json:"category_id"`
+		Type       def.QuestionType   `bson:"type" json:"type"`
+		Options    []QuestionOption   `bson:"options" json:"options"`
+		Code       QuestionCode       `bson:"code" json:"code"`
+		Rubric     QuestionRubric     `bson:"rubric" json:"rubric"`
+		CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+		UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+	}
+
+	QuestionOption struct {
+		ID        string `bson:"id" json:"id"`
+		Text      string `bson:"text" json:"text"`
+		IsCorrect bool   `bson:"is_correct" json:"is_correct"`
+	}
+
+	QuestionCode struct {
+		Stub  string         `bson:"stub" json:"stub"`
+		Tests []QuestionTest `bson:"tests" json:"tests"`
+	}
+
+	QuestionTest struct {
+		Name string `bson:"name" json:"name"`
+		Code string `bson:"code" json:"code"`
+	}
+
+	QuestionRubric struct {
+		ReferenceAnswer string              `bson:"reference_answer" json:"reference_answer"`
+		KeyPoints       []string            `bson:"key_points" json:"key_points"`
+		Criteria        []QuestionCriterion `bson:"criteria" json:"criteria"`
+	}
+
+	QuestionCriterion struct {
+		Name   string `bson:"name" json:"name"`
+		Weight int    `bson:"weight" json:"weight"`
+	}
+)
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"tech_check/internal/def"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SecurityEvent struct {
+	ID        primitive.ObjectID    `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID    `bson:"user_id" json:"user_id"`
+	Type      def.SecurityEventType `bson:"type" json:"type"`
+	IP        string                `bson:"ip" json:"ip"`
+	UserAgent string                `bson:"user_agent" json:"user_agent"`
+	Details   map[string]string     `bson:"details" json:"details"`
+	CreatedAt time.Time             `bson:"created_at" json:"created_at"`
+}
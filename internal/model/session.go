@@ -7,12 +7,38 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-type Session struct {
-	ID         primitive.ObjectID `bson:"_id" json:"id"`
-	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
-	CategoryID primitive.ObjectID `bson:"category_id" json:"category_id"`
-	Grade      def.GradeName      `bson:"grade" json:"grade"`
-	Summary    string             `bson:"summary" json:"summary"`
-	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
-	FinishedAt *time.Time         `bson:"finished_at" json:"finished_at"`
-}
+type (
+	Session struct {
+		ID                      primitive.ObjectID   `bson:"_id" json:"id"`
+		UserID                  primitive.ObjectID   `bson:"user_id" json:"user_id"`
+		CategoryID              primitive.ObjectID   `bson:"category_id" json:"category_id"`
+		Grade                   def.GradeName        `bson:"grade" json:"grade"`
+		TemplateID              *primitive.ObjectID  `bson:"template_id" json:"template_id"`
+		Mode                    def.SessionMode      `bson:"mode" json:"mode"`
+		EstimatedGrade          def.GradeName        `bson:"estimated_grade" json:"estimated_grade,omitempty"`
+		QuestionTimeLimitSecond int                  `bson:"question_time_limit_second" json:"question_time_limit_second"`
+		Summary                 string               `bson:"summary" json:"summary"`
+		SummaryStatus           def.EvaluationStatus `bson:"summary_status" json:"summary_status"`
+		Report                  *SessionReport       `bson:"report" json:"report"`
+		CreatedAt               time.Time            `bson:"created_at" json:"created_at"`
+		ExpiresAt               *time.Time           `bson:"expires_at" json:"expires_at"`
+		FinishedAt              *time.Time           `bson:"finished_at" json:"finished_at"`
+	}
+
+	SessionReport struct {
+		Score            int             `bson:"score" json:"score"`
+		Answered         int             `bson:"answered" json:"answered"`
+		Total            int             `bson:"total" json:"total"`
+		RecommendedGrade def.GradeName   `bson:"recommended_grade" json:"recommended_grade"`
+		Verdict          def.VerdictName `bson:"verdict" json:"verdict"`
+		Topics           []SessionTopic  `bson:"topics" json:"topics"`
+	}
+
+	SessionTopic struct {
+		CategoryID primitive.ObjectID `bson:"category_id" json:"category_id"`
+		Name       string             `bson:"name" json:"name"`
+		Score      int                `bson:"score" json:"score"`
+		Answered   int                `bson:"answered" json:"answered"`
+		Total      int                `bson:"total" json:"total"`
+	}
+)
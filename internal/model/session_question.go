@@ -1,17 +1,48 @@
 package model
 
 import (
+	"tech_check/internal/def"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-type SessionQuestion struct {
-	ID        primitive.ObjectID `bson:"_id" json:"id"`
-	SessionID primitive.ObjectID `bson:"session_id" json:"session_id"`
-	Text      string             `bson:"text" json:"text"`
-	Answer    string             `bson:"answer" json:"answer"`
-	Summary   string             `bson:"summary" json:"summary"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
-}
+type (
+	SessionQuestion struct {
+		ID               primitive.ObjectID   `bson:"_id" json:"id"`
+		SessionID        primitive.ObjectID   `bson:"session_id" json:"session_id"`
+		QuestionID       primitive.ObjectID   `bson:"question_id" json:"question_id"`
+		Text             string               `bson:"text" json:"text"`
+		Grade            def.GradeName        `bson:"grade" json:"grade"`
+		CategoryID       primitive.ObjectID   `bson:"category_id" json:"category_id"`
+		Type             def.QuestionType     `bson:"type" json:"type"`
+		Options          []SessionOption      `bson:"options" json:"options"`
+		CorrectOptionIDs []string             `bson:"correct_option_ids" json:"-"`
+		Stub             string               `bson:"stub" json:"stub"`
+		Tests            []QuestionTest       `bson:"tests" json:"-"`
+		Answer           string               `bson:"answer" json:"answer"`
+		AnswerOptionIDs  []string             `bson:"answer_option_ids" json:"answer_option_ids"`
+		Summary          string               `bson:"summary" json:"summary"`
+		Score            int                  `bson:"score" json:"score"`
+		Strengths        []string             `bson:"strengths" json:"strengths"`
+		Weaknesses       []string             `bson:"weaknesses" json:"weaknesses"`
+		TestResults      []TestResult         `bson:"test_results" json:"test_results"`
+		EvaluationStatus def.EvaluationStatus `bson:"evaluation_status" json:"evaluation_status"`
+		Rubric           QuestionRubric       `bson:"rubric" json:"-"`
+		SourceUpdatedAt  time.Time            `bson:"source_updated_at" json:"source_updated_at"`
+		OpenedAt         *time.Time           `bson:"opened_at" json:"opened_at"`
+		CreatedAt        time.Time            `bson:"created_at" json:"created_at"`
+		UpdatedAt        time.Time            `bson:"updated_at" json:"updated_at"`
+	}
+
+	TestResult struct {
+		Name   string `bson:"name" json:"name"`
+		Passed bool   `bson:"passed" json:"passed"`
+		Output string `bson:"output" json:"output"`
+	}
+
+	SessionOption struct {
+		ID   string `bson:"id" json:"id"`
+		Text string `bson:"text" json:"text"`
+	}
+)
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"tech_check/internal/def"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type (
+	SessionTemplate struct {
+		ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+		Name        string             `bson:"name" json:"name"`
+		Description string             `bson:"description" json:"description"`
+		Buckets     []SessionBucket    `bson:"buckets" json:"buckets"`
+		CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+		UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	}
+
+	SessionBucket struct {
+		CategoryID primitive.ObjectID `bson:"category_id" json:"category_id"`
+		Grade      def.GradeName      `bson:"grade" json:"grade"`
+		Count      int                `bson:"count" json:"count"`
+	}
+)
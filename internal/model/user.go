@@ -7,12 +7,25 @@ import (
 )
 
 type User struct {
-	ID        primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
-	Email     string               `bson:"email" json:"email"`
-	Name      string               `bson:"name" json:"name"`
-	Password  string               `bson:"password" json:"-"`
-	Avatar    string               `bson:"avatar" json:"avatar"`
-	CreatedAt time.Time            `bson:"created_at" json:"created_at"`
-	UpdatedAt time.Time            `bson:"updated_at" json:"updated_at"`
-	RoleIDs   []primitive.ObjectID `bson:"role_ids" json:"role_ids"`
+	ID                       primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Email                    string               `bson:"email" json:"email"`
+	Name                     string               `bson:"name" json:"name"`
+	Password                 string               `bson:"password" json:"-"`
+	Avatar                   string               `bson:"avatar" json:"avatar"`
+	TokenVersion             int                  `bson:"token_version" json:"-"`
+	EmailVerificationPending bool                 `bson:"email_verification_pending" json:"email_verification_pending"`
+	TOTPEnabled              bool                 `bson:"totp_enabled" json:"totp_enabled"`
+	TOTPSecret               string               `bson:"totp_secret" json:"-"`
+	TOTPLastStep             int64                `bson:"totp_last_step" json:"-"`
+	RecoveryCodes            []string             `bson:"recovery_codes" json:"-"`
+	CreatedAt                time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt                time.Time            `bson:"updated_at" json:"updated_at"`
+	RoleIDs                  []primitive.ObjectID `bson:"role_ids" json:"role_ids"`
+	ScopedRoles              []ScopedRole         `bson:"scoped_roles" json:"scoped_roles"`
+}
+
+// ScopedRole grants the permissions of a role only for one category.
+type ScopedRole struct {
+	RoleID     primitive.ObjectID `bson:"role_id" json:"role_id"`
+	CategoryID primitive.ObjectID `bson:"category_id" json:"category_id"`
 }
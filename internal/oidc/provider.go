@@ -0,0 +1,196 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type (
+	// ProviderConfig describes one issuer. Tokens must carry
+	// email_verified: true unless AllowUnverifiedEmail is set for a
+	// provider that only issues verified addresses but omits the claim.
+	ProviderConfig struct {
+		Name                 string `json:"name"`
+		Issuer               string `json:"issuer"`
+		ClientID             string `json:"client_id"`
+		EmailClaim           string `json:"email_claim"`
+		NameClaim            string `json:"name_claim"`
+		PictureClaim         string `json:"picture_claim"`
+		AllowUnverifiedEmail bool   `json:"allow_unverified_email"`
+	}
+
+	// provider caches the discovery document and the signing keys of one
+	// issuer. Keys are refetched when the cache expires or when a token
+	// references an unknown kid, the latter at most once per minRefresh.
+	provider struct {
+		cfg        ProviderConfig
+		client     *http.Client
+		cacheTTL   time.Duration
+		minRefresh time.Duration
+
+		mu        sync.Mutex
+		jwksURI   string
+		keys      map[string]interface{}
+		fetchedAt time.Time
+	}
+
+	discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+)
+
+func newProvider(cfg ProviderConfig, client *http.Client, cacheTTL time.Duration) *provider {
+	cfg.Issuer = strings.TrimRight(cfg.Issuer, "/")
+	if cfg.EmailClaim == "" {
+		cfg.EmailClaim = "email"
+	}
+	if cfg.NameClaim == "" {
+		cfg.NameClaim = "name"
+	}
+	if cfg.PictureClaim == "" {
+		cfg.PictureClaim = "picture"
+	}
+
+	return &provider{
+		cfg:        cfg,
+		client:     client,
+		cacheTTL:   cacheTTL,
+		minRefresh: time.Minute,
+	}
+}
+
+func (p *provider) verify(ctx context.Context, idToken string) (*dto.OIDCIdentity, error) {
+	const op = "oidc.provider.verify"
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(
+		idToken,
+		claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.key(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, def.ErrInvalidIDToken, err)
+	}
+
+	verified, _ := claims["email_verified"].(bool)
+	if !verified && !p.cfg.AllowUnverifiedEmail {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidIDToken)
+	}
+
+	email, _ := claims[p.cfg.EmailClaim].(string)
+	if email == "" {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidIDToken)
+	}
+	name, _ := claims[p.cfg.NameClaim].(string)
+	if name == "" {
+		name = email
+	}
+	picture, _ := claims[p.cfg.PictureClaim].(string)
+
+	return &dto.OIDCIdentity{
+		Email:   email,
+		Name:    name,
+		Picture: picture,
+	}, nil
+}
+
+func (p *provider) key(ctx context.Context, kid string) (interface{}, error) {
+	const op = "oidc.provider.key"
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	age := time.Since(p.fetchedAt)
+	key, ok := p.keys[kid]
+	if ok && age < p.cacheTTL {
+		return key, nil
+	}
+
+	if p.keys == nil || age >= p.cacheTTL || age >= p.minRefresh {
+		err := p.refresh(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		key, ok = p.keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrUnknownSigningKey)
+	}
+
+	return key, nil
+}
+
+func (p *provider) refresh(ctx context.Context) error {
+	const op = "oidc.provider.refresh"
+
+	if p.jwksURI == "" {
+		var doc discovery
+		err := p.get(ctx, p.cfg.Issuer+"/.well-known/openid-configuration", &doc)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if strings.TrimRight(doc.Issuer, "/") != p.cfg.Issuer || doc.JWKSURI == "" {
+			return fmt.Errorf("%s: discovery document does not match issuer %q", op, p.cfg.Issuer)
+		}
+		p.jwksURI = doc.JWKSURI
+	}
+
+	var set jwks
+	err := p.get(ctx, p.jwksURI, &set)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	keys, err := set.parse()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	p.keys = keys
+	p.fetchedAt = time.Now()
+
+	return nil
+}
+
+func (p *provider) get(ctx context.Context, url string, v interface{}) error {
+	const op = "oidc.provider.get"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s responded with %d", op, url, resp.StatusCode)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"tech_check/internal/def"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// issuer serves a discovery document and the public part of its keys.
+type issuer struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PrivateKey
+	jwksFetches int
+}
+
+func newIssuer(t *testing.T) *issuer {
+	t.Helper()
+
+	i := issuer{keys: make(map[string]*rsa.PrivateKey)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(discovery{
+			Issuer:  i.server.URL,
+			JWKSURI: i.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+
+		i.jwksFetches++
+		var set jwks
+		for kid, key := range i.keys {
+			set.Keys = append(set.Keys, jwk{
+				Kty: "RSA",
+				Use: "sig",
+				Kid: kid,
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(set)
+	})
+	i.server = httptest.NewServer(mux)
+	t.Cleanup(i.server.Close)
+
+	i.addKey(t, "k1")
+
+	return &i
+}
+
+func (i *issuer) addKey(t *testing.T, kid string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i.mu.Lock()
+	i.keys[kid] = key
+	i.mu.Unlock()
+}
+
+func (i *issuer) fetches() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.jwksFetches
+}
+
+func (i *issuer) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            i.server.URL,
+		"aud":            "tech_check",
+		"sub":            "1",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"email":          "user@example.com",
+		"email_verified": true,
+		"name":           "User",
+		"picture":        "https://example.com/user.png",
+	}
+}
+
+func (i *issuer) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	i.mu.Lock()
+	key := i.keys[kid]
+	i.mu.Unlock()
+	if key == nil {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func (i *issuer) provider(cfg ProviderConfig) *provider {
+	cfg.Name = "test"
+	cfg.Issuer = i.server.URL
+	if cfg.ClientID == "" {
+		cfg.ClientID = "tech_check"
+	}
+
+	return newProvider(cfg, i.server.Client(), time.Hour)
+}
+
+func TestProviderVerify(t *testing.T) {
+	i := newIssuer(t)
+
+	tests := []struct {
+		name    string
+		cfg     ProviderConfig
+		kid     string
+		claims  func(claims jwt.MapClaims)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			kid:    "k1",
+			claims: func(claims jwt.MapClaims) {},
+		},
+		{
+			name:    "wrong issuer",
+			kid:     "k1",
+			claims:  func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			kid:     "k1",
+			claims:  func(claims jwt.MapClaims) { claims["aud"] = "other_client" },
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			kid:     "k1",
+			claims:  func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+			wantErr: true,
+		},
+		{
+			name:    "no expiration",
+			kid:     "k1",
+			claims:  func(claims jwt.MapClaims) { delete(claims, "exp") },
+			wantErr: true,
+		},
+		{
+			name:    "signed by another key",
+			kid:     "unknown",
+			claims:  func(claims jwt.MapClaims) {},
+			wantErr: true,
+		},
+		{
+			name:    "email not verified",
+			kid:     "k1",
+			claims:  func(claims jwt.MapClaims) { claims["email_verified"] = false },
+			wantErr: true,
+		},
+		{
+			name:    "email verification missing",
+			kid:     "k1",
+			claims:  func(claims jwt.MapClaims) { delete(claims, "email_verified") },
+			wantErr: true,
+		},
+		{
+			name:   "email verification missing, allowed by config",
+			cfg:    ProviderConfig{AllowUnverifiedEmail: true},
+			kid:    "k1",
+			claims: func(claims jwt.MapClaims) { delete(claims, "email_verified") },
+		},
+		{
+			name:    "no email",
+			kid:     "k1",
+			claims:  func(claims jwt.MapClaims) { delete(claims, "email") },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := i.claims()
+			tt.claims(claims)
+
+			identity, err := i.provider(tt.cfg).verify(context.Background(), i.sign(t, tt.kid, claims))
+			if tt.wantErr {
+				if !errors.Is(err, def.ErrInvalidIDToken) {
+					t.Fatalf("verify() error = %v, want %v", err, def.ErrInvalidIDToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify() error = %v", err)
+			}
+
+			if identity.Email != "user@example.com" || identity.Name != "User" || identity.Picture != "https://example.com/user.png" {
+				t.Errorf("verify() = %+v", identity)
+			}
+		})
+	}
+}
+
+func TestProviderRefetchesUnknownKid(t *testing.T) {
+	i := newIssuer(t)
+	p := i.provider(ProviderConfig{})
+
+	_, err := p.verify(context.Background(), i.sign(t, "k1", i.claims()))
+	if err != nil {
+		t.Fatalf("verify() error = %v", err)
+	}
+	if i.fetches() != 1 {
+		t.Fatalf("jwks fetches = %d, want 1", i.fetches())
+	}
+
+	i.addKey(t, "k2")
+	token := i.sign(t, "k2", i.claims())
+
+	_, err = p.verify(context.Background(), token)
+	if !errors.Is(err, def.ErrInvalidIDToken) {
+		t.Fatalf("verify() within minRefresh error = %v, want %v", err, def.ErrInvalidIDToken)
+	}
+	if i.fetches() != 1 {
+		t.Fatalf("jwks fetches within minRefresh = %d, want 1", i.fetches())
+	}
+
+	p.minRefresh = 0
+	_, err = p.verify(context.Background(), token)
+	if err != nil {
+		t.Fatalf("verify() after rotation error = %v", err)
+	}
+	if i.fetches() != 2 {
+		t.Errorf("jwks fetches = %d, want 2", i.fetches())
+	}
+
+	_, err = p.verify(context.Background(), i.sign(t, "k1", i.claims()))
+	if err != nil {
+		t.Fatalf("verify() with cached key error = %v", err)
+	}
+	if i.fetches() != 2 {
+		t.Errorf("jwks fetches with cached key = %d, want 2", i.fetches())
+	}
+}
@@ -0,0 +1,41 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"time"
+)
+
+type Registry struct {
+	providers map[string]*provider
+}
+
+func NewRegistry(client *http.Client, cacheTTL time.Duration, configs []ProviderConfig) *Registry {
+	providers := make(map[string]*provider, len(configs))
+	for _, cfg := range configs {
+		providers[cfg.Name] = newProvider(cfg, client, cacheTTL)
+	}
+
+	return &Registry{
+		providers: providers,
+	}
+}
+
+func (r *Registry) Verify(ctx context.Context, providerName, idToken string) (*dto.OIDCIdentity, error) {
+	const op = "oidc.Registry.Verify"
+
+	p, ok := r.providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrUnknownProvider)
+	}
+
+	identity, err := p.verify(ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return identity, nil
+}
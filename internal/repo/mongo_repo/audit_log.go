@@ -0,0 +1,113 @@
+package mongo_repo
+
+import (
+	"context"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditLog struct {
+	maxListCount int
+	collection   *mongo.Collection
+}
+
+func NewAuditLog(db *mongo.Database) *AuditLog {
+	return &AuditLog{
+		maxListCount: 200,
+		collection:   db.Collection(def.TableAuditLogs.String()),
+	}
+}
+
+func (a *AuditLog) List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.AuditLog, *dto.Pagination, error) {
+	const op = "mongo_repo.AuditLog.List"
+
+	if count > a.maxListCount {
+		count = a.maxListCount
+	}
+
+	filter := bson.M{}
+	createdAt := bson.M{}
+	for key, value := range filters {
+		if key == "action" || key == "target_type" || key == "request_id" {
+			filter[key] = value
+		} else if key == "actor_id" || key == "target_id" {
+			filter[key] = bson.M{"$in": objectIDs(value)}
+		} else if key == "from" || key == "to" {
+			at, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				continue
+			}
+			if key == "from" {
+				createdAt["$gte"] = at
+			} else {
+				createdAt["$lte"] = at
+			}
+		}
+	}
+	if len(createdAt) > 0 {
+		filter["created_at"] = createdAt
+	}
+
+	sort := bson.D{}
+	for key, value := range sorts {
+		if key == "created_at" {
+			if value == "asc" {
+				sort = append(sort, bson.E{Key: key, Value: 1})
+			} else if value == "desc" {
+				sort = append(sort, bson.E{Key: key, Value: -1})
+			}
+		}
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSkip(int64((page - 1) * count))
+	findOptions.SetLimit(int64(count))
+	findOptions.SetSort(sort)
+
+	cursor, err := a.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer cursor.Close(ctx)
+
+	var auditLogs []model.AuditLog
+	err = cursor.All(ctx, &auditLogs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	total, err := a.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pagination := dto.Pagination{
+		Page:  page,
+		Count: count,
+		Total: int(total),
+	}
+
+	return auditLogs, &pagination, nil
+}
+
+func (a *AuditLog) Create(ctx context.Context, auditLog *model.AuditLog) error {
+	const op = "mongo_repo.AuditLog.Create"
+
+	auditLog.ID = primitive.NewObjectID()
+	auditLog.CreatedAt = time.Now()
+
+	_, err := a.collection.InsertOne(ctx, auditLog)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
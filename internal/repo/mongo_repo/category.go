@@ -40,6 +40,8 @@ func (c *Category) List(ctx context.Context, page, count int, filters, sorts map
 			filter[key] = bson.M{"$regex": value, "$options": "i"}
 		} else if key == "slug" {
 			filter[key] = value
+		} else if key == "ids" {
+			filter["_id"] = bson.M{"$in": objectIDs(value)}
 		}
 	}
 
@@ -0,0 +1,21 @@
+package mongo_repo
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// objectIDs parses a comma separated id list from a list filter, invalid
+// ids are skipped so an empty result matches nothing.
+func objectIDs(value string) []primitive.ObjectID {
+	ids := []primitive.ObjectID{}
+	for _, hex := range strings.Split(value, ",") {
+		id, err := primitive.ObjectIDFromHex(strings.TrimSpace(hex))
+		if err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
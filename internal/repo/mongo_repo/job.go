@@ -0,0 +1,108 @@
+package mongo_repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Job struct {
+	collection *mongo.Collection
+}
+
+func NewJob(db *mongo.Database) *Job {
+	return &Job{
+		collection: db.Collection(def.TableJobs.String()),
+	}
+}
+
+func (j *Job) Create(ctx context.Context, job *model.Job) error {
+	const op = "mongo_repo.Job.Create"
+
+	job.ID = primitive.NewObjectID()
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+
+	_, err := j.collection.InsertOne(ctx, job)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (j *Job) ClaimNext(ctx context.Context, now, staleBefore time.Time) (*model.Job, error) {
+	const op = "mongo_repo.Job.ClaimNext"
+
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{
+				"status": def.JobPending,
+				"run_at": bson.M{"$lte": now},
+			},
+			bson.M{
+				"status":    def.JobRunning,
+				"locked_at": bson.M{"$lt": staleBefore},
+			},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     def.JobRunning,
+			"locked_at":  now,
+			"updated_at": now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job model.Job
+	err := j.collection.FindOneAndUpdate(ctx, filter, update, findOptions).Decode(&job)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &job, nil
+}
+
+func (j *Job) Update(ctx context.Context, job *model.Job) error {
+	const op = "mongo_repo.Job.Update"
+
+	job.UpdatedAt = time.Now()
+
+	filter := bson.M{"_id": job.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     job.Status,
+			"attempts":   job.Attempts,
+			"last_error": job.LastError,
+			"run_at":     job.RunAt,
+			"locked_at":  job.LockedAt,
+			"updated_at": job.UpdatedAt,
+		},
+	}
+
+	result, err := j.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package mongo_repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Lockout struct {
+	maxListCount int
+	collection   *mongo.Collection
+}
+
+func NewLockout(db *mongo.Database) *Lockout {
+	return &Lockout{
+		maxListCount: 200,
+		collection:   db.Collection(def.TableLockouts.String()),
+	}
+}
+
+func (l *Lockout) List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.Lockout, *dto.Pagination, error) {
+	const op = "mongo_repo.Lockout.List"
+
+	if count > l.maxListCount {
+		count = l.maxListCount
+	}
+
+	filter := bson.M{}
+	for key, value := range filters {
+		if key == "kind" || key == "key" {
+			filter[key] = value
+		} else if key == "is_locked" && value == "1" {
+			filter["locked_until"] = bson.M{"$gt": time.Now()}
+		}
+	}
+
+	sort := bson.D{}
+	for key, value := range sorts {
+		if key == "created_at" ||
+			key == "updated_at" ||
+			key == "failures" ||
+			key == "locked_until" {
+			if value == "asc" {
+				sort = append(sort, bson.E{Key: key, Value: 1})
+			} else if value == "desc" {
+				sort = append(sort, bson.E{Key: key, Value: -1})
+			}
+		}
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSkip(int64((page - 1) * count))
+	findOptions.SetLimit(int64(count))
+	findOptions.SetSort(sort)
+
+	cursor, err := l.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer cursor.Close(ctx)
+
+	var lockouts []model.Lockout
+	err = cursor.All(ctx, &lockouts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	total, err := l.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pagination := dto.Pagination{
+		Page:  page,
+		Count: count,
+		Total: int(total),
+	}
+
+	return lockouts, &pagination, nil
+}
+
+func (l *Lockout) GetByID(ctx context.Context, id string) (*model.Lockout, error) {
+	const op = "mongo_repo.Lockout.GetByID"
+
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	filter := bson.M{"_id": idObj}
+	var lockout model.Lockout
+
+	err = l.collection.FindOne(ctx, filter).Decode(&lockout)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &lockout, nil
+}
+
+func (l *Lockout) GetByKey(ctx context.Context, kind def.LockoutKind, key string) (*model.Lockout, error) {
+	const op = "mongo_repo.Lockout.GetByKey"
+
+	filter := bson.M{"kind": kind, "key": key}
+	var lockout model.Lockout
+
+	err := l.collection.FindOne(ctx, filter).Decode(&lockout)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &lockout, nil
+}
+
+// IncFailures atomically counts a failed attempt, starting the counter over
+// when the previous failure happened before resetBefore.
+func (l *Lockout) IncFailures(ctx context.Context, kind def.LockoutKind, key string, now, resetBefore time.Time) (*model.Lockout, error) {
+	const op = "mongo_repo.Lockout.IncFailures"
+
+	filter := bson.M{"kind": kind, "key": key}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"failures": bson.M{"$cond": bson.A{
+				bson.M{"$lt": bson.A{"$updated_at", resetBefore}},
+				1,
+				bson.M{"$add": bson.A{"$failures", 1}},
+			}},
+			"created_at": bson.M{"$ifNull": bson.A{"$created_at", now}},
+			"updated_at": now,
+		}}},
+	}
+	findOptions := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var lockout model.Lockout
+	err := l.collection.FindOneAndUpdate(ctx, filter, update, findOptions).Decode(&lockout)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &lockout, nil
+}
+
+func (l *Lockout) SetLockedUntil(ctx context.Context, lockout *model.Lockout, lockedUntil time.Time) error {
+	const op = "mongo_repo.Lockout.SetLockedUntil"
+
+	lockout.LockedUntil = &lockedUntil
+
+	filter := bson.M{"_id": lockout.ID}
+	update := bson.M{"$set": bson.M{"locked_until": lockout.LockedUntil}}
+
+	_, err := l.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (l *Lockout) Delete(ctx context.Context, id string) error {
+	const op = "mongo_repo.Lockout.Delete"
+
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	filter := bson.M{"_id": idObj}
+
+	result, err := l.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (l *Lockout) DeleteByKey(ctx context.Context, kind def.LockoutKind, key string) error {
+	const op = "mongo_repo.Lockout.DeleteByKey"
+
+	filter := bson.M{"kind": kind, "key": key}
+
+	_, err := l.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
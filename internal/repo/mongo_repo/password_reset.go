@@ -0,0 +1,77 @@
+package mongo_repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PasswordReset struct {
+	collection *mongo.Collection
+}
+
+func NewPasswordReset(db *mongo.Database) *PasswordReset {
+	return &PasswordReset{
+		collection: db.Collection(def.TablePasswordResets.String()),
+	}
+}
+
+func (p *PasswordReset) Create(ctx context.Context, passwordReset *model.PasswordReset) error {
+	const op = "mongo_repo.PasswordReset.Create"
+
+	passwordReset.ID = primitive.NewObjectID()
+	passwordReset.CreatedAt = time.Now()
+
+	_, err := p.collection.InsertOne(ctx, passwordReset)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *PasswordReset) DeleteByUser(ctx context.Context, user *model.User) error {
+	const op = "mongo_repo.PasswordReset.DeleteByUser"
+
+	filter := bson.M{"user_id": user.ID}
+
+	_, err := p.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Consume marks an unused, unexpired reset as used in a single update, so a
+// token cannot be redeemed twice by concurrent requests.
+func (p *PasswordReset) Consume(ctx context.Context, hash string, now time.Time) (*model.PasswordReset, error) {
+	const op = "mongo_repo.PasswordReset.Consume"
+
+	filter := bson.M{
+		"hash":       hash,
+		"used_at":    nil,
+		"expires_at": bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{"used_at": now}}
+	findOptions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var passwordReset model.PasswordReset
+	err := p.collection.FindOneAndUpdate(ctx, filter, update, findOptions).Decode(&passwordReset)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &passwordReset, nil
+}
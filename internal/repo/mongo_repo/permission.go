@@ -124,3 +124,66 @@ func (p *Permission) GetByID(ctx context.Context, id string) (*model.Permission,
 
 	return &permission, nil
 }
+
+// CreateIfMissing inserts the permission unless one with the same slug
+// exists, existing permissions are left untouched.
+func (p *Permission) CreateIfMissing(ctx context.Context, permission *model.Permission) (bool, error) {
+	const op = "mongo_repo.Permission.CreateIfMissing"
+
+	filter := bson.M{"slug": permission.Slug}
+	update := bson.M{"$setOnInsert": bson.M{
+		"name":       permission.Name,
+		"created_at": time.Now(),
+		"updated_at": time.Now(),
+	}}
+	updateOptions := options.Update().SetUpsert(true)
+
+	result, err := p.collection.UpdateOne(ctx, filter, update, updateOptions)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.UpsertedCount > 0, nil
+}
+
+func (p *Permission) ListSlugs(ctx context.Context) ([]string, error) {
+	const op = "mongo_repo.Permission.ListSlugs"
+
+	values, err := p.collection.Distinct(ctx, "slug", bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slugs := make([]string, 0, len(values))
+	for _, value := range values {
+		slug, ok := value.(string)
+		if ok {
+			slugs = append(slugs, slug)
+		}
+	}
+
+	return slugs, nil
+}
+
+func (p *Permission) ListByIDs(ctx context.Context, ids []primitive.ObjectID) ([]model.Permission, error) {
+	const op = "mongo_repo.Permission.ListByIDs"
+
+	if len(ids) == 0 {
+		return []model.Permission{}, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": ids}}
+	cursor, err := p.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer cursor.Close(ctx)
+
+	var permissions []model.Permission
+	err = cursor.All(ctx, &permissions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return permissions, nil
+}
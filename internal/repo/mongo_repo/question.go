@@ -38,6 +38,8 @@ func (q *Question) List(ctx context.Context, page, count int, filters, sorts map
 	for key, value := range filters {
 		if key == "text" {
 			filter[key] = bson.M{"$regex": value, "$options": "i"}
+		} else if key == "category_ids" {
+			filter["category_id"] = bson.M{"$in": objectIDs(value)}
 		} else if key == "grade" {
 			_, err := def.ValidateGradeName(value)
 			if err == nil {
@@ -148,6 +150,10 @@ func (q *Question) Update(ctx context.Context, question *model.Question) error {
 		"$set": bson.M{
 			"text":       question.Text,
 			"grade":      question.Grade,
+			"type":       question.Type,
+			"options":    question.Options,
+			"code":       question.Code,
+			"rubric":     question.Rubric,
 			"updated_at": question.UpdatedAt,
 		},
 	}
@@ -186,13 +192,22 @@ func (q *Question) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (q *Question) GetRandom(ctx context.Context, category *model.Category, grade def.GradeName, count int) ([]model.Question, error) {
+func (q *Question) GetRandom(
+	ctx context.Context,
+	category *model.Category,
+	grade def.GradeName,
+	count int,
+	excludeIDs []primitive.ObjectID,
+) ([]model.Question, error) {
 	const op = "mongo_repo.Question.GetRandom"
 
 	filter := bson.M{
 		"grade":       grade,
 		"category_id": category.ID,
 	}
+	if len(excludeIDs) > 0 {
+		filter["_id"] = bson.M{"$nin": excludeIDs}
+	}
 	pipeline := mongo.Pipeline{
 		{{Key: "$match", Value: filter}},
 		{{Key: "$sample", Value: bson.M{"size": count}}},
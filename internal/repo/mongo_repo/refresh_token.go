@@ -11,6 +11,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type RefreshToken struct {
@@ -28,7 +29,7 @@ func (r *RefreshToken) DeleteByUser(ctx context.Context, user *model.User) error
 
 	filter := bson.M{"user_id": user.ID}
 
-	result, err := r.collection.DeleteOne(ctx, filter)
+	result, err := r.collection.DeleteMany(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -40,6 +41,70 @@ func (r *RefreshToken) DeleteByUser(ctx context.Context, user *model.User) error
 	return nil
 }
 
+func (r *RefreshToken) DeleteFamily(ctx context.Context, refreshToken *model.RefreshToken) error {
+	const op = "mongo_repo.RefreshToken.DeleteFamily"
+
+	filter := bson.M{
+		"user_id":   refreshToken.UserID,
+		"family_id": refreshToken.FamilyID,
+	}
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *RefreshToken) DeleteExpiredByUser(ctx context.Context, user *model.User, now time.Time) error {
+	const op = "mongo_repo.RefreshToken.DeleteExpiredByUser"
+
+	filter := bson.M{
+		"user_id":    user.ID,
+		"expires_at": bson.M{"$lte": now},
+	}
+
+	_, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *RefreshToken) ListByUser(ctx context.Context, user *model.User, now time.Time) ([]model.RefreshToken, error) {
+	const op = "mongo_repo.RefreshToken.ListByUser"
+
+	filter := bson.M{
+		"user_id":    user.ID,
+		"used_at":    nil,
+		"expires_at": bson.M{"$gt": now},
+	}
+	sort := bson.D{{Key: "created_at", Value: -1}}
+
+	findOptions := options.Find()
+	findOptions.SetSort(sort)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer cursor.Close(ctx)
+
+	refreshTokens := []model.RefreshToken{}
+	err = cursor.All(ctx, &refreshTokens)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refreshTokens, nil
+}
+
 func (r *RefreshToken) Create(ctx context.Context, refreshToken *model.RefreshToken) error {
 	const op = "mongo_repo.RefreshToken.Create"
 
@@ -78,3 +143,26 @@ func (r *RefreshToken) GetByUserAndID(ctx context.Context, user *model.User, id
 
 	return &refreshToken, nil
 }
+
+func (r *RefreshToken) MarkUsed(ctx context.Context, refreshToken *model.RefreshToken, now time.Time) error {
+	const op = "mongo_repo.RefreshToken.MarkUsed"
+
+	filter := bson.M{
+		"_id":     refreshToken.ID,
+		"used_at": nil,
+	}
+	update := bson.M{"$set": bson.M{"used_at": now}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.ModifiedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrRTokenReused)
+	}
+
+	refreshToken.UsedAt = &now
+
+	return nil
+}
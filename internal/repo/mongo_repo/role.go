@@ -176,8 +176,10 @@ func (r *Role) Update(ctx context.Context, role *model.Role) error {
 	update := bson.M{
 		"$set": bson.M{
 			"name":           role.Name,
+			"require_totp":   role.RequireTOTP,
 			"updated_at":     role.UpdatedAt,
 			"permission_ids": role.PermissionIDs,
+			"parent_ids":     role.ParentIDs,
 		},
 	}
 
@@ -192,3 +194,45 @@ func (r *Role) Update(ctx context.Context, role *model.Role) error {
 
 	return nil
 }
+
+// ListWithAncestors loads the roles and, level by level, every parent role
+// they inherit from. Already loaded roles are skipped, so a cycle stored
+// in the database cannot loop forever.
+func (r *Role) ListWithAncestors(ctx context.Context, ids []primitive.ObjectID) ([]model.Role, error) {
+	const op = "mongo_repo.Role.ListWithAncestors"
+
+	var roles []model.Role
+	visited := make(map[primitive.ObjectID]bool)
+	for len(ids) > 0 {
+		var pending []primitive.ObjectID
+		for _, id := range ids {
+			if !visited[id] {
+				visited[id] = true
+				pending = append(pending, id)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		filter := bson.M{"_id": bson.M{"$in": pending}}
+		cursor, err := r.collection.Find(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		var level []model.Role
+		err = cursor.All(ctx, &level)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		ids = nil
+		for _, role := range level {
+			roles = append(roles, role)
+			ids = append(ids, role.ParentIDs...)
+		}
+	}
+
+	return roles, nil
+}
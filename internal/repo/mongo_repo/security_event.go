@@ -0,0 +1,36 @@
+package mongo_repo
+
+import (
+	"context"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type SecurityEvent struct {
+	collection *mongo.Collection
+}
+
+func NewSecurityEvent(db *mongo.Database) *SecurityEvent {
+	return &SecurityEvent{
+		collection: db.Collection(def.TableSecurityEvents.String()),
+	}
+}
+
+func (s *SecurityEvent) Create(ctx context.Context, event *model.SecurityEvent) error {
+	const op = "mongo_repo.SecurityEvent.Create"
+
+	event.ID = primitive.NewObjectID()
+	event.CreatedAt = time.Now()
+
+	_, err := s.collection.InsertOne(ctx, event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
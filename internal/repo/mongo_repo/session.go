@@ -55,6 +55,38 @@ func (s *Session) IsExistsActive(ctx context.Context, user *model.User) (bool, e
 	return count > 0, nil
 }
 
+func (s *Session) ListExpired(ctx context.Context, now time.Time, count int) ([]model.Session, error) {
+	const op = "mongo_repo.Session.ListExpired"
+
+	if count > s.maxListCount {
+		count = s.maxListCount
+	}
+
+	filter := bson.M{
+		"finished_at": nil,
+		"expires_at":  bson.M{"$lte": now},
+	}
+	sort := bson.D{{Key: "expires_at", Value: 1}}
+
+	findOptions := options.Find()
+	findOptions.SetLimit(int64(count))
+	findOptions.SetSort(sort)
+
+	cursor, err := s.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []model.Session
+	err = cursor.All(ctx, &sessions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessions, nil
+}
+
 func (s *Session) GetByID(ctx context.Context, id string) (*model.Session, error) {
 	const op = "mongo_repo.Session.GetByID"
 
@@ -124,8 +156,11 @@ func (s *Session) Update(ctx context.Context, session *model.Session) error {
 	filter := bson.M{"_id": session.ID}
 	update := bson.M{
 		"$set": bson.M{
-			"summary":     session.Summary,
-			"finished_at": session.FinishedAt,
+			"estimated_grade": session.EstimatedGrade,
+			"summary":         session.Summary,
+			"summary_status":  session.SummaryStatus,
+			"report":          session.Report,
+			"finished_at":     session.FinishedAt,
 		},
 	}
 	result, err := s.collection.UpdateOne(ctx, filter, update)
@@ -139,3 +174,56 @@ func (s *Session) Update(ctx context.Context, session *model.Session) error {
 
 	return nil
 }
+
+// UpdateEstimatedGrade stores the grade of an active session only, so a
+// session finished meanwhile keeps its report.
+func (s *Session) UpdateEstimatedGrade(ctx context.Context, session *model.Session) error {
+	const op = "mongo_repo.Session.UpdateEstimatedGrade"
+
+	filter := bson.M{
+		"_id":         session.ID,
+		"finished_at": nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"estimated_grade": session.EstimatedGrade,
+		},
+	}
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrSessionFinished)
+	}
+
+	return nil
+}
+
+// Finish marks an active session finished, an already finished session
+// is not matched and reported as ErrSessionFinished.
+func (s *Session) Finish(ctx context.Context, session *model.Session) error {
+	const op = "mongo_repo.Session.Finish"
+
+	filter := bson.M{
+		"_id":         session.ID,
+		"finished_at": nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"summary_status": session.SummaryStatus,
+			"finished_at":    session.FinishedAt,
+		},
+	}
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrSessionFinished)
+	}
+
+	return nil
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"tech_check/internal/def"
+	"tech_check/internal/dto"
 	"tech_check/internal/model"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type SessionQuestion struct {
@@ -42,7 +44,12 @@ func (s *SessionQuestion) List(ctx context.Context, session *model.Session) ([]m
 	const op = "mongo_repo.SessionQuestion.List"
 
 	filter := bson.M{"session_id": session.ID}
-	cursor, err := s.collection.Find(ctx, filter)
+	sort := bson.D{{Key: "_id", Value: 1}}
+
+	findOptions := options.Find()
+	findOptions.SetSort(sort)
+
+	cursor, err := s.collection.Find(ctx, filter, findOptions)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -88,9 +95,119 @@ func (s *SessionQuestion) Update(ctx context.Context, question *model.SessionQue
 	filter := bson.M{"_id": question.ID}
 	update := bson.M{
 		"$set": bson.M{
-			"answer":    question.Answer,
-			"summary":   question.Summary,
-			"update_at": question.UpdatedAt,
+			"answer":            question.Answer,
+			"answer_option_ids": question.AnswerOptionIDs,
+			"summary":           question.Summary,
+			"score":             question.Score,
+			"strengths":         question.Strengths,
+			"weaknesses":        question.Weaknesses,
+			"test_results":      question.TestResults,
+			"updated_at":        question.UpdatedAt,
+			"evaluation_status": question.EvaluationStatus,
+			"opened_at":         question.OpenedAt,
+		},
+	}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	return nil
+}
+
+// UpdateChoiceAnswer stores the answer of a choice question only when it has
+// not been answered yet, a choice answer is final so options cannot be
+// tried one after another.
+func (s *SessionQuestion) UpdateChoiceAnswer(ctx context.Context, question *model.SessionQuestion) error {
+	const op = "mongo_repo.SessionQuestion.UpdateChoiceAnswer"
+
+	question.UpdatedAt = time.Now()
+	filter := bson.M{
+		"_id": question.ID,
+		"$or": bson.A{
+			bson.M{"answer_option_ids": nil},
+			bson.M{"answer_option_ids": bson.M{"$size": 0}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"answer":            question.Answer,
+			"answer_option_ids": question.AnswerOptionIDs,
+			"summary":           question.Summary,
+			"score":             question.Score,
+			"strengths":         question.Strengths,
+			"weaknesses":        question.Weaknesses,
+			"updated_at":        question.UpdatedAt,
+			"evaluation_status": question.EvaluationStatus,
+		},
+	}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrQuestionAnswered)
+	}
+
+	return nil
+}
+
+// Expire scores a question that was left unanswered, an answer stored
+// meanwhile is not overwritten and reported as ErrQuestionAnswered.
+func (s *SessionQuestion) Expire(ctx context.Context, question *model.SessionQuestion) error {
+	const op = "mongo_repo.SessionQuestion.Expire"
+
+	question.UpdatedAt = time.Now()
+	filter := bson.M{
+		"_id":    question.ID,
+		"answer": "",
+		"$or": bson.A{
+			bson.M{"answer_option_ids": nil},
+			bson.M{"answer_option_ids": bson.M{"$size": 0}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"summary":           question.Summary,
+			"score":             question.Score,
+			"updated_at":        question.UpdatedAt,
+			"evaluation_status": question.EvaluationStatus,
+		},
+	}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrQuestionAnswered)
+	}
+
+	return nil
+}
+
+// SetOpenedAt records the first time the question was served, a question
+// that is already open is left as it is.
+func (s *SessionQuestion) SetOpenedAt(ctx context.Context, question *model.SessionQuestion) error {
+	const op = "mongo_repo.SessionQuestion.SetOpenedAt"
+
+	question.UpdatedAt = time.Now()
+	filter := bson.M{
+		"_id":       question.ID,
+		"opened_at": nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"opened_at":  question.OpenedAt,
+			"updated_at": question.UpdatedAt,
 		},
 	}
 
@@ -105,3 +222,88 @@ func (s *SessionQuestion) Update(ctx context.Context, question *model.SessionQue
 
 	return nil
 }
+
+// UpdateEvaluation stores the evaluation fields only while the answer is
+// still the one that was evaluated, so a newer answer is neither
+// overwritten nor scored by a stale evaluation.
+func (s *SessionQuestion) UpdateEvaluation(ctx context.Context, question *model.SessionQuestion) error {
+	const op = "mongo_repo.SessionQuestion.UpdateEvaluation"
+
+	question.UpdatedAt = time.Now()
+	filter := bson.M{
+		"_id":    question.ID,
+		"answer": question.Answer,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"summary":           question.Summary,
+			"score":             question.Score,
+			"strengths":         question.Strengths,
+			"weaknesses":        question.Weaknesses,
+			"test_results":      question.TestResults,
+			"updated_at":        question.UpdatedAt,
+			"evaluation_status": question.EvaluationStatus,
+		},
+	}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (s *SessionQuestion) StatsByQuestion(ctx context.Context, question *model.Question) (*dto.QuestionStats, error) {
+	const op = "mongo_repo.SessionQuestion.StatsByQuestion"
+
+	isEvaluated := bson.M{"$eq": bson.A{"$evaluation_status", def.EvaluationDone}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"question_id": question.ID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   nil,
+			"asked": bson.M{"$sum": 1},
+			"answered": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$ne": bson.A{"$answer", ""}}, 1, 0},
+			}},
+			"evaluated": bson.M{"$sum": bson.M{
+				"$cond": bson.A{isEvaluated, 1, 0},
+			}},
+			"average_score": bson.M{"$avg": bson.M{
+				"$cond": bson.A{isEvaluated, "$score", nil},
+			}},
+			"asked_before_edit": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$lt": bson.A{"$source_updated_at", question.UpdatedAt}}, 1, 0},
+			}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"average_score": bson.M{"$toInt": bson.M{
+				"$round": bson.A{bson.M{"$ifNull": bson.A{"$average_score", 0}}, 0},
+			}},
+		}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer cursor.Close(ctx)
+
+	var stats dto.QuestionStats
+	if cursor.Next(ctx) {
+		err = cursor.Decode(&stats)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	err = cursor.Err()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,172 @@
+package mongo_repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SessionTemplate struct {
+	maxListCount int
+	collection   *mongo.Collection
+}
+
+func NewSessionTemplate(db *mongo.Database) *SessionTemplate {
+	return &SessionTemplate{
+		maxListCount: 200,
+		collection:   db.Collection(def.TableSessionTemplates.String()),
+	}
+}
+
+func (s *SessionTemplate) List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.SessionTemplate, *dto.Pagination, error) {
+	const op = "mongo_repo.SessionTemplate.List"
+
+	if count > s.maxListCount {
+		count = s.maxListCount
+	}
+
+	filter := bson.M{}
+	for key, value := range filters {
+		if key == "name" || key == "description" {
+			filter[key] = bson.M{"$regex": value, "$options": "i"}
+		}
+	}
+
+	sort := bson.D{}
+	for key, value := range sorts {
+		if key == "created_at" ||
+			key == "updated_at" ||
+			key == "name" {
+			if value == "asc" {
+				sort = append(sort, bson.E{Key: key, Value: 1})
+			} else if value == "desc" {
+				sort = append(sort, bson.E{Key: key, Value: -1})
+			}
+		}
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSkip(int64((page - 1) * count))
+	findOptions.SetLimit(int64(count))
+	findOptions.SetSort(sort)
+
+	cursor, err := s.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer cursor.Close(ctx)
+
+	var templates []model.SessionTemplate
+	err = cursor.All(ctx, &templates)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	total, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pagination := dto.Pagination{
+		Page:  page,
+		Count: count,
+		Total: int(total),
+	}
+
+	return templates, &pagination, nil
+}
+
+func (s *SessionTemplate) Create(ctx context.Context, template *model.SessionTemplate) error {
+	const op = "mongo_repo.SessionTemplate.Create"
+
+	template.ID = primitive.NewObjectID()
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = time.Now()
+
+	_, err := s.collection.InsertOne(ctx, &template)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *SessionTemplate) GetByID(ctx context.Context, id string) (*model.SessionTemplate, error) {
+	const op = "mongo_repo.SessionTemplate.GetByID"
+
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	filter := bson.M{"_id": idObj}
+	var template model.SessionTemplate
+
+	err = s.collection.FindOne(ctx, filter).Decode(&template)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &template, nil
+}
+
+func (s *SessionTemplate) Update(ctx context.Context, template *model.SessionTemplate) error {
+	const op = "mongo_repo.SessionTemplate.Update"
+
+	template.UpdatedAt = time.Now()
+
+	filter := bson.M{"_id": template.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"name":        template.Name,
+			"description": template.Description,
+			"buckets":     template.Buckets,
+			"updated_at":  template.UpdatedAt,
+		},
+	}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (s *SessionTemplate) Delete(ctx context.Context, id string) error {
+	const op = "mongo_repo.SessionTemplate.Delete"
+
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	filter := bson.M{"_id": idObj}
+
+	result, err := s.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	return nil
+}
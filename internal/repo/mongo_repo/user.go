@@ -128,11 +128,38 @@ func (u *User) Update(ctx context.Context, user *model.User) error {
 	filter := bson.M{"_id": user.ID}
 	update := bson.M{
 		"$set": bson.M{
-			"name":       user.Name,
-			"email":      user.Email,
-			"avatar":     user.Avatar,
+			"name":                       user.Name,
+			"email":                      user.Email,
+			"avatar":                     user.Avatar,
+			"updated_at":                 user.UpdatedAt,
+			"role_ids":                   user.RoleIDs,
+			"scoped_roles":               user.ScopedRoles,
+			"email_verification_pending": user.EmailVerificationPending,
+		},
+	}
+
+	result, err := u.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (u *User) UpdatePassword(ctx context.Context, user *model.User) error {
+	const op = "mongo_repo.User.UpdatePassword"
+
+	user.UpdatedAt = time.Now()
+
+	filter := bson.M{"_id": user.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"password":   user.Password,
 			"updated_at": user.UpdatedAt,
-			"role_ids":   user.RoleIDs,
 		},
 	}
 
@@ -148,6 +175,102 @@ func (u *User) Update(ctx context.Context, user *model.User) error {
 	return nil
 }
 
+func (u *User) UpdateTwoFactor(ctx context.Context, user *model.User) error {
+	const op = "mongo_repo.User.UpdateTwoFactor"
+
+	user.UpdatedAt = time.Now()
+
+	filter := bson.M{"_id": user.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"totp_enabled":   user.TOTPEnabled,
+			"totp_secret":    user.TOTPSecret,
+			"totp_last_step": user.TOTPLastStep,
+			"recovery_codes": user.RecoveryCodes,
+			"updated_at":     user.UpdatedAt,
+		},
+	}
+
+	result, err := u.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	return nil
+}
+
+// UseTOTPStep records the step of an accepted code only if no later or
+// equal step was recorded meanwhile, so a code is accepted once even when
+// two requests race.
+func (u *User) UseTOTPStep(ctx context.Context, user *model.User, step int64) error {
+	const op = "mongo_repo.User.UseTOTPStep"
+
+	user.UpdatedAt = time.Now()
+
+	filter := bson.M{
+		"_id":            user.ID,
+		"totp_last_step": bson.M{"$lt": step},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"totp_last_step": step,
+			"updated_at":     user.UpdatedAt,
+		},
+	}
+
+	result, err := u.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	user.TOTPLastStep = step
+
+	return nil
+}
+
+// UseRecoveryCode removes the hash only if it is still stored, so a
+// recovery code is accepted once even when two requests race.
+func (u *User) UseRecoveryCode(ctx context.Context, user *model.User, hash string) error {
+	const op = "mongo_repo.User.UseRecoveryCode"
+
+	user.UpdatedAt = time.Now()
+
+	filter := bson.M{
+		"_id":            user.ID,
+		"recovery_codes": hash,
+	}
+	update := bson.M{
+		"$pull": bson.M{"recovery_codes": hash},
+		"$set":  bson.M{"updated_at": user.UpdatedAt},
+	}
+
+	result, err := u.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+	}
+
+	for idx, recoveryCode := range user.RecoveryCodes {
+		if recoveryCode == hash {
+			user.RecoveryCodes = append(user.RecoveryCodes[:idx], user.RecoveryCodes[idx+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
 func (u *User) Delete(ctx context.Context, id string) error {
 	const op = "mongo_repo.User.Delete"
 
@@ -183,6 +306,29 @@ func (u *User) IsExistsEmail(ctx context.Context, email string) (bool, error) {
 	return count > 0, nil
 }
 
+func (u *User) IncTokenVersion(ctx context.Context, user *model.User) error {
+	const op = "mongo_repo.User.IncTokenVersion"
+
+	filter := bson.M{"_id": user.ID}
+	update := bson.M{"$inc": bson.M{"token_version": 1}}
+	findOptions := options.FindOneAndUpdate().
+		SetProjection(bson.M{"token_version": 1}).
+		SetReturnDocument(options.After)
+
+	var updated model.User
+	err := u.collection.FindOneAndUpdate(ctx, filter, update, findOptions).Decode(&updated)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("%s: %w", op, def.ErrNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	user.TokenVersion = updated.TokenVersion
+
+	return nil
+}
+
 func (u *User) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	const op = "mongo_repo.User.GetByEmail"
 
@@ -199,50 +345,3 @@ func (u *User) GetByEmail(ctx context.Context, email string) (*model.User, error
 
 	return &user, nil
 }
-
-func (u *User) HasPermission(ctx context.Context, user *model.User, permissionSlug string) (bool, error) {
-	const op = "mongo_repo.User.HasPermission"
-
-	if len(user.RoleIDs) == 0 {
-		return false, nil
-	}
-
-	roleFilter := bson.M{
-		"_id": bson.M{"$in": user.RoleIDs},
-	}
-	rolesCursor, err := u.collection.Database().
-		Collection(def.TableRoles.String()).
-		Find(ctx, roleFilter)
-	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
-	}
-	defer rolesCursor.Close(ctx)
-
-	var roles []model.Role
-	err = rolesCursor.All(ctx, &roles)
-	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
-	}
-
-	var permissionIDs []primitive.ObjectID
-	for _, role := range roles {
-		permissionIDs = append(permissionIDs, role.PermissionIDs...)
-	}
-
-	if len(permissionIDs) == 0 {
-		return false, nil
-	}
-
-	permissionFilter := bson.M{
-		"_id":  bson.M{"$in": permissionIDs},
-		"slug": permissionSlug,
-	}
-	count, err := u.collection.Database().
-		Collection(def.TablePermissions.String()).
-		CountDocuments(ctx, permissionFilter)
-	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
-	}
-
-	return count > 0, nil
-}
@@ -0,0 +1,447 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"time"
+)
+
+var (
+	packageClause = regexp.MustCompile(`^\s*package\s+\w+\s*\n`)
+	runLine       = regexp.MustCompile(`^=== (?:RUN|CONT)\s+(Test_\d+)`)
+	resultLine    = regexp.MustCompile(`^\s*--- (PASS|FAIL|SKIP): (Test_\d+)`)
+)
+
+type (
+	Go struct {
+		goBin          string
+		prlimitBin     string
+		timeout        time.Duration
+		memoryLimitMB  int
+		maxProcesses   int
+		uid            int
+		gid            int
+		outputLimit    int
+		testOutputMax  int
+		allowedImports map[string]bool
+	}
+
+	// report is a line the generated TestMain writes to the results file.
+	report struct {
+		Test   int  `json:"test"`
+		Passed bool `json:"passed"`
+		Done   bool `json:"done"`
+	}
+)
+
+// NewGo returns a runner that executes the compiled tests under rlimits,
+// in a fresh network namespace and, when started as root, as uid:gid.
+func NewGo(goBin, prlimitBin string, timeout time.Duration, memoryLimitMB, outputLimitKB, maxProcesses, uid, gid int) *Go {
+	return &Go{
+		goBin:         goBin,
+		prlimitBin:    prlimitBin,
+		timeout:       timeout,
+		memoryLimitMB: memoryLimitMB,
+		maxProcesses:  maxProcesses,
+		uid:           uid,
+		gid:           gid,
+		outputLimit:   outputLimitKB * 1024,
+		testOutputMax: 1024,
+		allowedImports: map[string]bool{
+			"bytes":           true,
+			"container/heap":  true,
+			"container/list":  true,
+			"container/ring":  true,
+			"errors":          true,
+			"fmt":             true,
+			"maps":            true,
+			"math":            true,
+			"math/bits":       true,
+			"regexp":          true,
+			"slices":          true,
+			"sort":            true,
+			"strconv":         true,
+			"strings":         true,
+			"sync":            true,
+			"unicode":         true,
+			"unicode/utf8":    true,
+			"unicode/utf16":   true,
+			"encoding/json":   true,
+			"encoding/base64": true,
+			"encoding/hex":    true,
+		},
+	}
+}
+
+func (g *Go) Run(ctx context.Context, code string, tests []model.QuestionTest) (*dto.CodeRun, error) {
+	const op = "runner.Go.Run"
+
+	source := "package solution\n\n" + packageClause.ReplaceAllString(code, "")
+
+	err := g.checkImports(source)
+	if err != nil {
+		return g.failed(tests, err.Error()), nil
+	}
+
+	nonce, err := g.nonce()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	dir, err := os.MkdirTemp("", "tech_check_run_")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"go.mod":           "module solution\n\ngo 1.23\n",
+		"solution.go":      source,
+		"solution_test.go": g.testFile(tests, nonce),
+	}
+	for name, content := range files {
+		err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	output, ok, err := g.build(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !ok {
+		return g.failed(tests, output), nil
+	}
+
+	output, reports, err := g.exec(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &dto.CodeRun{Results: g.parse(output, reports, tests)}, nil
+}
+
+func (g *Go) checkImports(source string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), "solution.go", source, parser.ImportsOnly)
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+		if !g.allowedImports[path] {
+			return fmt.Errorf("import %q is not allowed", path)
+		}
+	}
+
+	return nil
+}
+
+// nonce names the reporting helpers of a run. The candidate code shares the
+// package with the tests and could otherwise call them to fake a result.
+func (g *Go) nonce() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// testFile wraps every test so its verdict is written to fd 3 by a TestMain
+// the candidate cannot reach. Candidate code may only import allowed
+// packages, so it has no handle to that file, and whatever it prints to
+// stdout never counts as a result.
+func (g *Go) testFile(tests []model.QuestionTest, nonce string) string {
+	var sb strings.Builder
+
+	sb.WriteString("package solution\n\n")
+	sb.WriteString("import (\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"os\"\n\t\"reflect\"\n\t\"strings\"\n\t\"testing\"\n)\n\n")
+	sb.WriteString("var (\n\t_ = fmt.Sprint\n\t_ = reflect.DeepEqual\n\t_ = strings.Join\n)\n")
+
+	fmt.Fprintf(&sb, "\nvar results_%[1]s = json.NewEncoder(os.NewFile(3, \"results\"))\n", nonce)
+	fmt.Fprintf(&sb, "\nfunc report_%[1]s(t *testing.T, idx int) {\n"+
+		"\tresults_%[1]s.Encode(map[string]interface{}{\"test\": idx, \"passed\": !t.Failed()})\n}\n", nonce)
+	fmt.Fprintf(&sb, "\nfunc TestMain(m *testing.M) {\n"+
+		"\tcode := m.Run()\n"+
+		"\tresults_%[1]s.Encode(map[string]interface{}{\"done\": true})\n"+
+		"\tos.Exit(code)\n}\n", nonce)
+
+	for idx, test := range tests {
+		fmt.Fprintf(&sb, "\nfunc %s(t *testing.T) {\n\tdefer report_%s(t, %d)\n%s\n}\n", g.testName(idx), nonce, idx, test.Code)
+	}
+
+	return sb.String()
+}
+
+func (g *Go) testName(idx int) string {
+	return fmt.Sprintf("Test_%03d", idx)
+}
+
+func (g *Go) build(ctx context.Context, dir string) (string, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	output := newLimitedBuffer(g.outputLimit)
+	cmd := exec.CommandContext(ctx, g.goBin, "test", "-c", "-o", "solution.test", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GOFLAGS=-mod=mod",
+		"GOPROXY=off",
+		"GOWORK=off",
+		"GOTOOLCHAIN=local",
+		"CGO_ENABLED=0",
+	)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return "build timed out", false, nil
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return output.String(), false, nil
+		}
+		return "", false, err
+	}
+
+	return output.String(), true, nil
+}
+
+// exec runs the test binary through prlimit, which caps its heap
+// (RLIMIT_DATA, as the Go runtime reserves more address space than
+// RLIMIT_AS would allow), cpu time, file size and, under a dedicated uid,
+// the number of threads. sysProcAttr adds the network namespace and the
+// unprivileged credentials.
+func (g *Go) exec(ctx context.Context, dir string) (string, []report, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	sysProcAttr, err := g.sysProcAttr()
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = g.share(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	results, err := os.CreateTemp("", "tech_check_results_")
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.Remove(results.Name())
+	defer results.Close()
+
+	limits := []string{
+		fmt.Sprintf("--data=%d", g.memoryLimitMB*1024*1024),
+		fmt.Sprintf("--cpu=%d", max(1, int(g.timeout.Seconds()))),
+		fmt.Sprintf("--fsize=%d", g.outputLimit),
+		"--core=0",
+	}
+	if sysProcAttr.Credential != nil {
+		limits = append(limits, fmt.Sprintf("--nproc=%d", g.maxProcesses))
+	}
+
+	args := append(limits, "--",
+		filepath.Join(dir, "solution.test"),
+		"-test.v",
+		"-test.count=1",
+		fmt.Sprintf("-test.timeout=%s", g.timeout),
+	)
+
+	output := newLimitedBuffer(g.outputLimit)
+	cmd := exec.CommandContext(ctx, g.prlimitBin, args...)
+	cmd.Dir = dir
+	cmd.Env = []string{
+		"HOME=" + dir,
+		"TMPDIR=" + dir,
+		"GOMAXPROCS=1",
+	}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.ExtraFiles = []*os.File{results}
+	cmd.SysProcAttr = sysProcAttr
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) && ctx.Err() == nil {
+			return "", nil, err
+		}
+	}
+
+	reports, err := g.readReports(results)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return output.String(), reports, nil
+}
+
+// share lets the unprivileged test process execute the binary without
+// being able to list the directory or read the hidden test sources.
+func (g *Go) share(dir string) error {
+	err := os.Chmod(dir, 0o711)
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(filepath.Join(dir, "solution.test"), 0o755)
+}
+
+func (g *Go) readReports(results *os.File) ([]report, error) {
+	_, err := results.Seek(0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []report
+	scanner := bufio.NewScanner(results)
+	for scanner.Scan() {
+		var r report
+		if json.Unmarshal(scanner.Bytes(), &r) == nil {
+			reports = append(reports, r)
+		}
+	}
+
+	return reports, scanner.Err()
+}
+
+// parse takes the verdicts from the reports only, stdout is used for the
+// failure output shown to the candidate. Without the final report the run
+// was cut short, by a timeout or a crash, and every test fails.
+func (g *Go) parse(output string, reports []report, tests []model.QuestionTest) []model.TestResult {
+	results := make([]model.TestResult, len(tests))
+	indexes := make(map[string]int, len(tests))
+	for idx, test := range tests {
+		indexes[g.testName(idx)] = idx
+		results[idx] = model.TestResult{
+			Name:   test.Name,
+			Output: "test did not complete",
+		}
+	}
+
+	isDone := false
+	passed := make([]bool, len(tests))
+	for _, r := range reports {
+		if r.Done {
+			isDone = true
+		} else if r.Test >= 0 && r.Test < len(tests) {
+			passed[r.Test] = r.Passed
+		}
+	}
+	if !isDone {
+		for idx := range results {
+			results[idx].Output = "test run did not complete"
+		}
+		return results
+	}
+
+	logs := make([]strings.Builder, len(tests))
+	current := -1
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if match := runLine.FindStringSubmatch(line); match != nil {
+			idx, ok := indexes[match[1]]
+			current = -1
+			if ok {
+				current = idx
+			}
+			continue
+		}
+
+		if resultLine.MatchString(line) {
+			current = -1
+			continue
+		}
+
+		if current >= 0 && logs[current].Len() < g.testOutputMax {
+			logs[current].WriteString(strings.TrimSpace(line))
+			logs[current].WriteString("\n")
+		}
+	}
+
+	for idx := range results {
+		results[idx].Passed = passed[idx]
+		results[idx].Output = ""
+		if !passed[idx] {
+			results[idx].Output = strings.TrimSpace(logs[idx].String())
+			if results[idx].Output == "" {
+				results[idx].Output = "test failed"
+			}
+		}
+	}
+
+	return results
+}
+
+func (g *Go) failed(tests []model.QuestionTest, output string) *dto.CodeRun {
+	results := make([]model.TestResult, 0, len(tests))
+	for _, test := range tests {
+		results = append(results, model.TestResult{Name: test.Name})
+	}
+
+	if len(output) > g.testOutputMax {
+		output = output[:g.testOutputMax]
+	}
+
+	return &dto.CodeRun{
+		BuildOutput: output,
+		Results:     results,
+	}
+}
+
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func newLimitedBuffer(limit int) *limitedBuffer {
+	return &limitedBuffer{limit: limit}
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	left := b.limit - b.buf.Len()
+	if left > 0 {
+		if len(p) > left {
+			b.buf.Write(p[:left])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
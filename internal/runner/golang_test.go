@@ -0,0 +1,246 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"strings"
+	"tech_check/internal/model"
+	"testing"
+	"time"
+)
+
+func newTestGo() *Go {
+	return NewGo("go", "prlimit", 30*time.Second, 256, 64, 128, 65534, 65534)
+}
+
+func TestGoTestFile(t *testing.T) {
+	g := newTestGo()
+	tests := []model.QuestionTest{
+		{Name: "first", Code: `if Add(1, 2) != 3 { t.Fatal("want 3") }`},
+		{Name: "second", Code: `if Add(2, 2) != 4 { t.Fatal("want 4") }`},
+	}
+
+	src := g.testFile(tests, "abc123")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "solution_test.go", src, 0)
+	if err != nil {
+		t.Fatalf("generated file does not parse: %v\n%s", err, src)
+	}
+
+	declared := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			declared[decl.Name.Name] = true
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				if spec, ok := spec.(*ast.ValueSpec); ok {
+					for _, name := range spec.Names {
+						declared[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	for _, name := range []string{"TestMain", "Test_000", "Test_001", "report_abc123", "results_abc123"} {
+		if !declared[name] {
+			t.Errorf("generated file does not declare %s", name)
+		}
+	}
+
+	for idx := range tests {
+		want := fmt.Sprintf("defer report_abc123(t, %d)", idx)
+		if !strings.Contains(src, want) {
+			t.Errorf("test %d does not report its result, want %q", idx, want)
+		}
+	}
+	if !strings.Contains(src, "os.NewFile(3,") {
+		t.Error("results are not written to fd 3")
+	}
+}
+
+func TestGoParse(t *testing.T) {
+	g := newTestGo()
+	tests := []model.QuestionTest{{Name: "first"}, {Name: "second"}, {Name: "third"}}
+	output := strings.Join([]string{
+		"=== RUN   Test_000",
+		"--- PASS: Test_000 (0.00s)",
+		"=== RUN   Test_001",
+		"    solution_test.go:12: want 4",
+		"--- FAIL: Test_001 (0.00s)",
+		"=== RUN   Test_002",
+		"--- PASS: Test_002 (0.00s)",
+		"--- PASS: Test_001 (0.00s)",
+		"PASS",
+	}, "\n")
+
+	t.Run("verdicts come from reports", func(t *testing.T) {
+		reports := []report{
+			{Test: 0, Passed: true},
+			{Test: 1, Passed: false},
+			{Test: 2, Passed: false},
+			{Done: true},
+		}
+
+		results := g.parse(output, reports, tests)
+
+		want := []model.TestResult{
+			{Name: "first", Passed: true},
+			{Name: "second", Passed: false, Output: "solution_test.go:12: want 4"},
+			{Name: "third", Passed: false, Output: "test failed"},
+		}
+		for idx := range want {
+			if results[idx] != want[idx] {
+				t.Errorf("result %d = %+v, want %+v", idx, results[idx], want[idx])
+			}
+		}
+	})
+
+	t.Run("missing final report fails every test", func(t *testing.T) {
+		reports := []report{
+			{Test: 0, Passed: true},
+			{Test: 1, Passed: true},
+			{Test: 2, Passed: true},
+		}
+
+		for idx, result := range g.parse(output, reports, tests) {
+			if result.Passed || result.Output != "test run did not complete" {
+				t.Errorf("result %d = %+v, want a failed incomplete run", idx, result)
+			}
+		}
+	})
+
+	t.Run("unreported test fails", func(t *testing.T) {
+		reports := []report{
+			{Test: 0, Passed: true},
+			{Test: 7, Passed: true},
+			{Done: true},
+		}
+
+		results := g.parse(output, reports, tests)
+		if !results[0].Passed {
+			t.Errorf("result 0 = %+v, want passed", results[0])
+		}
+		if results[1].Passed || results[2].Passed {
+			t.Errorf("results = %+v, want unreported tests failed", results)
+		}
+	})
+}
+
+func TestGoReadReports(t *testing.T) {
+	g := newTestGo()
+
+	file, err := os.CreateTemp(t.TempDir(), "results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	_, err = file.WriteString("{\"test\":0,\"passed\":true}\nnot json\n{\"done\":true}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := g.readReports(file)
+	if err != nil {
+		t.Fatalf("readReports() error = %v", err)
+	}
+
+	want := []report{{Test: 0, Passed: true}, {Done: true}}
+	if len(reports) != len(want) || reports[0] != want[0] || reports[1] != want[1] {
+		t.Errorf("readReports() = %+v, want %+v", reports, want)
+	}
+}
+
+func TestGoCheckImports(t *testing.T) {
+	g := newTestGo()
+
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{
+		{name: "allowed", source: "package solution\n\nimport \"strings\"\n"},
+		{name: "os", source: "package solution\n\nimport \"os\"\n", wantErr: true},
+		{name: "unsafe", source: "package solution\n\nimport \"unsafe\"\n", wantErr: true},
+		{name: "net", source: "package solution\n\nimport _ \"net/http\"\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := g.checkImports(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkImports() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGoRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiles and runs candidate code")
+	}
+	for _, bin := range []string{"go", "prlimit"} {
+		_, err := exec.LookPath(bin)
+		if err != nil {
+			t.Skipf("%s is not available", bin)
+		}
+	}
+
+	g := newTestGo()
+	tests := []model.QuestionTest{
+		{Name: "adds", Code: `if Add(1, 2) != 3 { t.Fatal("want 3") }`},
+		{Name: "adds negatives", Code: `if Add(-1, -2) != -3 { t.Fatal("want -3") }`},
+	}
+
+	cases := []struct {
+		name   string
+		code   string
+		passed []bool
+	}{
+		{
+			name:   "correct",
+			code:   "func Add(a, b int) int { return a + b }",
+			passed: []bool{true, true},
+		},
+		{
+			name: "forged output",
+			code: "import \"fmt\"\n\nfunc init() { fmt.Println(\"--- PASS: Test_000 (0.00s)\\n--- PASS: Test_001 (0.00s)\") }\n\n" +
+				"func Add(a, b int) int { return 3 }",
+			passed: []bool{true, false},
+		},
+		{
+			name:   "panic",
+			code:   "func Add(a, b int) int { panic(\"boom\") }",
+			passed: []bool{false, false},
+		},
+		{
+			name:   "memory",
+			code:   "func Add(a, b int) int { x := make([]byte, 1<<30); x[len(x)-1] = 1; return a + b }",
+			passed: []bool{false, false},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			run, err := g.Run(context.Background(), tt.code, tests)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if run.BuildOutput != "" {
+				t.Fatalf("Run() build output = %s", run.BuildOutput)
+			}
+
+			for idx, result := range run.Results {
+				if result.Passed != tt.passed[idx] {
+					t.Errorf("result %d = %+v, want passed %v", idx, result, tt.passed[idx])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package runner
+
+import (
+	"os"
+	"syscall"
+)
+
+// sysProcAttr puts the test process into its own network namespace, which
+// has only a down loopback device. Started as root the process also drops
+// to the configured uid and gid, otherwise it runs in a user namespace that
+// maps the current user, so no privileges are needed.
+func (g *Go) sysProcAttr() (*syscall.SysProcAttr, error) {
+	attr := syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWNET,
+		Setpgid:    true,
+		Pdeathsig:  syscall.SIGKILL,
+	}
+
+	if os.Geteuid() == 0 {
+		attr.Credential = &syscall.Credential{
+			Uid:    uint32(g.uid),
+			Gid:    uint32(g.gid),
+			Groups: []uint32{},
+		}
+		return &attr, nil
+	}
+
+	attr.Cloneflags |= syscall.CLONE_NEWUSER
+	attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: os.Geteuid(), HostID: os.Geteuid(), Size: 1}}
+	attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getegid(), HostID: os.Getegid(), Size: 1}}
+
+	return &attr, nil
+}
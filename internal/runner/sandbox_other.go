@@ -0,0 +1,14 @@
+//go:build !linux
+
+package runner
+
+import (
+	"errors"
+	"syscall"
+)
+
+// sysProcAttr refuses to run candidate code outside linux, where the
+// network namespace the sandbox relies on is not available.
+func (g *Go) sysProcAttr() (*syscall.SysProcAttr, error) {
+	return nil, errors.New("code runner sandbox is only supported on linux")
+}
@@ -0,0 +1,192 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type (
+	// JWT signs access tokens with the key selected by signingKID and
+	// verifies tokens with any configured key, so a retiring key keeps
+	// validating tokens until it is removed from the configuration.
+	// Without asymmetric keys it falls back to HS512 with the shared secret.
+	JWT struct {
+		secret     []byte
+		signingKID string
+		keys       map[string]*key
+	}
+
+	key struct {
+		method  jwt.SigningMethod
+		private interface{}
+		public  interface{}
+	}
+)
+
+func NewJWT(secret string, keyFiles map[string]string, signingKID string) (*JWT, error) {
+	const op = "signer.NewJWT"
+
+	j := JWT{
+		secret:     []byte(secret),
+		signingKID: signingKID,
+		keys:       make(map[string]*key, len(keyFiles)),
+	}
+
+	for kid, path := range keyFiles {
+		k, err := loadKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: key %q: %w", op, kid, err)
+		}
+		j.keys[kid] = k
+	}
+
+	if signingKID == "" {
+		if len(j.secret) == 0 {
+			return nil, fmt.Errorf("%s: either secret or signing key is required", op)
+		}
+		return &j, nil
+	}
+
+	k, ok := j.keys[signingKID]
+	if !ok {
+		return nil, fmt.Errorf("%s: signing key %q is not configured", op, signingKID)
+	}
+	if k.private == nil {
+		return nil, fmt.Errorf("%s: signing key %q has no private part", op, signingKID)
+	}
+
+	return &j, nil
+}
+
+func (j *JWT) Sign(claims jwt.Claims) (string, error) {
+	const op = "signer.JWT.Sign"
+
+	if j.signingKID == "" {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString(j.secret)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		return token, nil
+	}
+
+	k := j.keys[j.signingKID]
+	token := jwt.NewWithClaims(k.method, claims)
+	token.Header["kid"] = j.signingKID
+
+	signed, err := token.SignedString(k.private)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return signed, nil
+}
+
+func (j *JWT) Key(token *jwt.Token) (interface{}, error) {
+	const op = "signer.JWT.Key"
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		_, ok := token.Method.(*jwt.SigningMethodHMAC)
+		if !ok || len(j.secret) == 0 {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidSigningMethod)
+		}
+		return j.secret, nil
+	}
+
+	k, ok := j.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrUnknownSigningKey)
+	}
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidSigningMethod)
+	}
+
+	return k.public, nil
+}
+
+func (j *JWT) JWKS() *dto.JWKS {
+	kids := make([]string, 0, len(j.keys))
+	for kid := range j.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	jwks := dto.JWKS{Keys: make([]dto.JWK, 0, len(kids))}
+	for _, kid := range kids {
+		k := j.keys[kid]
+		jwk := dto.JWK{
+			Use: "sig",
+			Alg: k.method.Alg(),
+			Kid: kid,
+		}
+
+		switch public := k.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return &jwks
+}
+
+// loadKey accepts a PKCS#8 or PKCS#1 private key or a PKIX public key.
+// Retired keys may be configured with the public part only.
+func loadKey(path string) (*key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &key{method: jwt.SigningMethodRS256, private: k, public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &key{method: jwt.SigningMethodRS256, public: k}, nil
+	case ed25519.PrivateKey:
+		return &key{method: jwt.SigningMethodEdDSA, private: k, public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &key{method: jwt.SigningMethodEdDSA, public: k}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+}
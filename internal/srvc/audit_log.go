@@ -0,0 +1,131 @@
+package srvc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditLog struct {
+	auditLogRepo AuditLogRepo
+	lg           *slog.Logger
+}
+
+func NewAuditLog(auditLogRepo AuditLogRepo, lg *slog.Logger) *AuditLog {
+	return &AuditLog{
+		auditLogRepo: auditLogRepo,
+		lg:           lg,
+	}
+}
+
+func (a *AuditLog) List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.AuditLog, *dto.Pagination, error) {
+	const op = "srvc.AuditLog.List"
+
+	auditLogs, pagination, err := a.auditLogRepo.List(ctx, page, count, filters, sorts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auditLogs, pagination, nil
+}
+
+// Record stores the fields that differ between the before snapshot and
+// the after target, together with the authenticated user and the request
+// id found in ctx. A nil before or after stands for a created or deleted
+// target.
+//
+// Auditing is best effort: Record runs once the change is committed, so a
+// failure is logged rather than failing a request whose change was made.
+func (a *AuditLog) Record(
+	ctx context.Context,
+	action def.AuditAction,
+	targetType def.AuditTarget,
+	targetID primitive.ObjectID,
+	before map[string]interface{},
+	after interface{},
+) {
+	const op = "srvc.AuditLog.Record"
+
+	err := a.record(ctx, action, targetType, targetID, before, after)
+	if err != nil {
+		a.lg.Error(
+			fmt.Errorf("%s: %w", op, err).Error(),
+			slog.String("action", action.String()),
+			slog.String("target_id", targetID.Hex()),
+		)
+	}
+}
+
+func (a *AuditLog) record(
+	ctx context.Context,
+	action def.AuditAction,
+	targetType def.AuditTarget,
+	targetID primitive.ObjectID,
+	before map[string]interface{},
+	after interface{},
+) error {
+	var afterSnapshot map[string]interface{}
+	if after != nil {
+		var err error
+		afterSnapshot, err = auditSnapshot(after)
+		if err != nil {
+			return err
+		}
+	}
+
+	auditLog := model.AuditLog{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Changes:    auditDiff(before, afterSnapshot),
+	}
+	if actor, ok := ctx.Value(def.ContextAuthUser).(*model.User); ok {
+		auditLog.ActorID = &actor.ID
+		auditLog.ActorEmail = actor.Email
+	}
+	if requestID, ok := ctx.Value(def.HeaderRequestID).(string); ok {
+		auditLog.RequestID = requestID
+	}
+
+	return a.auditLogRepo.Create(ctx, &auditLog)
+}
+
+// auditSnapshot copies the json view of v, so fields hidden from the api
+// never reach the audit log and later changes to v don't alter the copy.
+func auditSnapshot(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot map[string]interface{}
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func auditDiff(before, after map[string]interface{}) map[string]model.AuditChange {
+	changes := make(map[string]model.AuditChange)
+	for key, value := range before {
+		if !reflect.DeepEqual(value, after[key]) {
+			changes[key] = model.AuditChange{Before: value, After: after[key]}
+		}
+	}
+	for key, value := range after {
+		if _, ok := before[key]; !ok {
+			changes[key] = model.AuditChange{Before: nil, After: value}
+		}
+	}
+
+	return changes
+}
@@ -6,82 +6,194 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"log"
+	"net/url"
 	"tech_check/internal/def"
 	"tech_check/internal/dto"
 	"tech_check/internal/model"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/api/idtoken"
 )
 
 type Auth struct {
-	rTokenExpiresHour int
-	aTokenExpiresHour int
-	rTokenLength      int
-	googleClientID    string
-	jwtSecret         []byte
-	userSrvc          UserSrvc
-	refreshTokenSrvc  RefreshTokenSrvc
+	rTokenExpiresHour      int
+	aTokenExpiresHour      int
+	rTokenLength           int
+	challengeExpiresMinute int
+	googleClientID         string
+	passwordResetURL       string
+	tokenSigner            TokenSigner
+	oidcVerifier           OIDCVerifier
+	userSrvc               UserSrvc
+	refreshTokenSrvc       RefreshTokenSrvc
+	securityEventSrvc      SecurityEventSrvc
+	mailSrvc               MailSrvc
+	jobSrvc                JobSrvc
+	passwordResetSrvc      PasswordResetSrvc
+	twoFactorSrvc          TwoFactorSrvc
+	lockoutSrvc            LockoutSrvc
 }
 
-func NewAuth(googleClientID, jwtSecret string, userSrvc UserSrvc, refreshTokenSrvc RefreshTokenSrvc) *Auth {
+func NewAuth(
+	googleClientID, passwordResetURL string,
+	tokenSigner TokenSigner,
+	oidcVerifier OIDCVerifier,
+	userSrvc UserSrvc,
+	refreshTokenSrvc RefreshTokenSrvc,
+	securityEventSrvc SecurityEventSrvc,
+	mailSrvc MailSrvc,
+	jobSrvc JobSrvc,
+	passwordResetSrvc PasswordResetSrvc,
+	twoFactorSrvc TwoFactorSrvc,
+	lockoutSrvc LockoutSrvc,
+) *Auth {
 	return &Auth{
-		rTokenExpiresHour: 24,
-		aTokenExpiresHour: 2,
-		rTokenLength:      50,
-		googleClientID:    googleClientID,
-		jwtSecret:         []byte(jwtSecret),
-		userSrvc:          userSrvc,
-		refreshTokenSrvc:  refreshTokenSrvc,
+		rTokenExpiresHour:      24,
+		aTokenExpiresHour:      2,
+		rTokenLength:           50,
+		challengeExpiresMinute: 5,
+		googleClientID:         googleClientID,
+		passwordResetURL:       passwordResetURL,
+		tokenSigner:            tokenSigner,
+		oidcVerifier:           oidcVerifier,
+		userSrvc:               userSrvc,
+		refreshTokenSrvc:       refreshTokenSrvc,
+		securityEventSrvc:      securityEventSrvc,
+		mailSrvc:               mailSrvc,
+		jobSrvc:                jobSrvc,
+		passwordResetSrvc:      passwordResetSrvc,
+		twoFactorSrvc:          twoFactorSrvc,
+		lockoutSrvc:            lockoutSrvc,
 	}
 }
 
-func (a *Auth) Login(ctx context.Context, email, password, ip string) (*dto.Token, error) {
+// Login returns a challenge instead of a token when the user has two-factor
+// authentication enabled, the token is issued by VerifyChallenge.
+func (a *Auth) Login(ctx context.Context, email, password, ip, userAgent string) (*dto.Token, *dto.Challenge, error) {
 	const op = "srvc.Auth.Login"
 
+	err := a.lockoutSrvc.Check(ctx, email, ip)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	user, err := a.validateCredential(ctx, email, password)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, def.ErrInvalidCredentials) {
+			lockErr := a.lockoutSrvc.Fail(ctx, email, ip)
+			if lockErr != nil {
+				return nil, nil, fmt.Errorf("%s: %w", op, lockErr)
+			}
+		}
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	token, err := a.createToken(ctx, user, ip)
+	token, challenge, err := a.signIn(ctx, user, ip, userAgent)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return token, nil
+	return token, challenge, nil
 }
 
-func (a *Auth) GoogleLogin(ctx context.Context, tokenID, ip string) (*dto.Token, error) {
+func (a *Auth) GoogleLogin(ctx context.Context, tokenID, ip, userAgent string) (*dto.Token, *dto.Challenge, error) {
 	const op = "srvc.Auth.GoogleLogin"
 
 	payload, err := idtoken.Validate(ctx, tokenID, a.googleClientID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	email, ok := payload.Claims["email"].(string)
 	if !ok {
-		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidGoogleData)
+		return nil, nil, fmt.Errorf("%s: %w", op, def.ErrInvalidGoogleData)
 	}
 	name, ok := payload.Claims["name"].(string)
 	if !ok {
-		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidGoogleData)
+		return nil, nil, fmt.Errorf("%s: %w", op, def.ErrInvalidGoogleData)
 	}
 	avatar, ok := payload.Claims["picture"].(string)
 	if !ok {
-		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidGoogleData)
+		return nil, nil, fmt.Errorf("%s: %w", op, def.ErrInvalidGoogleData)
 	}
 
 	user, err := a.userSrvc.GetOrCreate(ctx, email, name, avatar)
 	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, challenge, err := a.signIn(ctx, user, ip, userAgent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, challenge, nil
+}
+
+func (a *Auth) OIDCLogin(ctx context.Context, provider, idToken, ip, userAgent string) (*dto.Token, *dto.Challenge, error) {
+	const op = "srvc.Auth.OIDCLogin"
+
+	identity, err := a.oidcVerifier.Verify(ctx, provider, idToken)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := a.userSrvc.GetOrCreate(ctx, identity.Email, identity.Name, identity.Picture)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, challenge, err := a.signIn(ctx, user, ip, userAgent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, challenge, nil
+}
+
+func (a *Auth) VerifyChallenge(ctx context.Context, challengeToken, code, ip, userAgent string) (*dto.Token, error) {
+	const op = "srvc.Auth.VerifyChallenge"
+
+	claims := jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(
+		challengeToken,
+		&claims,
+		a.tokenSigner.Key,
+		jwt.WithAudience(def.AudienceLoginChallenge.String()),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidChallenge)
+	}
+
+	user, err := a.userSrvc.GetByID(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, def.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrCannotLogin)
+		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	token, err := a.createToken(ctx, user, ip)
+	err = a.lockoutSrvc.Check(ctx, user.Email, ip)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.twoFactorSrvc.Verify(ctx, user, code)
+	if err != nil {
+		if errors.Is(err, def.ErrInvalidTwoFactorCode) {
+			lockErr := a.lockoutSrvc.Fail(ctx, user.Email, ip)
+			if lockErr != nil {
+				return nil, fmt.Errorf("%s: %w", op, lockErr)
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err := a.issueToken(ctx, user, ip, userAgent)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -92,20 +204,20 @@ func (a *Auth) GoogleLogin(ctx context.Context, tokenID, ip string) (*dto.Token,
 func (a *Auth) DecodeAToken(ctx context.Context, aToken string) (*dto.Claims, error) {
 	const op = "srvc.Auth.DecodeAToken"
 
-	token, err := jwt.ParseWithClaims(aToken, &dto.Claims{}, a.getSigningKey)
+	token, err := jwt.ParseWithClaims(aToken, &dto.Claims{}, a.tokenSigner.Key)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	claims, ok := token.Claims.(*dto.Claims)
-	if !ok {
+	if !ok || len(claims.Audience) > 0 {
 		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidClaimsType)
 	}
 
 	return claims, nil
 }
 
-func (a *Auth) Refresh(ctx context.Context, aToken, rToken, ip string) (*dto.Token, error) {
+func (a *Auth) Refresh(ctx context.Context, aToken, rToken, ip, userAgent string) (*dto.Token, error) {
 	const op = "srvc.Auth.Refresh"
 
 	claims, err := a.DecodeAToken(ctx, aToken)
@@ -121,17 +233,347 @@ func (a *Auth) Refresh(ctx context.Context, aToken, rToken, ip string) (*dto.Tok
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	refreshToken, err := a.validateRToken(ctx, user, claims.RefreshTokenID.Hex(), rToken)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	if claims.IP != ip {
-		// TODO: send email
-		log.Printf("sending email to %s", user.Email)
+		err = a.mailSrvc.Enqueue(ctx, user, def.MailIPChanged, map[string]string{
+			def.MailKeyIP:        ip,
+			def.MailKeyUserAgent: userAgent,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if refreshToken.UsedAt == nil {
+		err = a.refreshTokenSrvc.MarkUsed(ctx, refreshToken)
+	} else {
+		err = def.ErrRTokenReused
+	}
+	if err != nil {
+		if errors.Is(err, def.ErrRTokenReused) {
+			err = a.revokeFamily(ctx, user, refreshToken, ip, userAgent)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			return nil, fmt.Errorf("%s: %w", op, def.ErrRTokenReused)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err := a.createToken(ctx, user, refreshToken.FamilyID, ip, userAgent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+// Me hides the permissions while a role requires two-factor authentication
+// the user has not enabled, HasPermission refuses them in that case too.
+func (a *Auth) Me(ctx context.Context, user *model.User) (*dto.Me, error) {
+	const op = "srvc.Auth.Me"
+
+	permissions, err := a.userSrvc.EffectivePermissions(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	me := dto.Me{
+		User:              *user,
+		Permissions:       expandSlugs(permissions.Slugs),
+		ScopedPermissions: make(map[string][]string),
+	}
+	for granted, categoryIDs := range permissions.Scoped {
+		for _, slug := range expandSlugs([]string{granted}) {
+			me.ScopedPermissions[slug] = append(me.ScopedPermissions[slug], categoryIDs...)
+		}
+	}
+	if permissions.RequiresTOTP && !user.TOTPEnabled {
+		me.Permissions = []string{}
+		me.ScopedPermissions = map[string][]string{}
+		me.TwoFactorRequired = true
+	}
+
+	return &me, nil
+}
+
+func (a *Auth) JWKS() *dto.JWKS {
+	return a.tokenSigner.JWKS()
+}
+
+func (a *Auth) Logout(ctx context.Context, user *model.User, refreshTokenID string) error {
+	const op = "srvc.Auth.Logout"
+
+	refreshToken, err := a.refreshTokenSrvc.GetByUserAndID(ctx, user, refreshTokenID)
+	if err != nil {
+		if errors.Is(err, def.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.refreshTokenSrvc.DeleteFamily(ctx, refreshToken)
+	if err != nil && !errors.Is(err, def.ErrNotFound) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *Auth) LogoutAll(ctx context.Context, user *model.User) error {
+	const op = "srvc.Auth.LogoutAll"
+
+	err := a.revokeAll(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ForgotPassword does not report unknown emails, so the endpoint cannot be
+// used to find out who has an account. The lookup runs in a job, known and
+// unknown emails take the same path and time, and every request counts
+// against the password reset counters of the email and the ip.
+func (a *Auth) ForgotPassword(ctx context.Context, email, ip string) error {
+	const op = "srvc.Auth.ForgotPassword"
+
+	err := a.lockoutSrvc.CheckPasswordReset(ctx, email, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.lockoutSrvc.FailPasswordReset(ctx, email, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = a.jobSrvc.Enqueue(ctx, def.JobPasswordReset, map[string]string{
+		def.JobKeyEmail: email,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SendPasswordReset runs the JobPasswordReset job queued by ForgotPassword.
+// The mail is sent from this job, the reset link is never stored in a job
+// payload.
+func (a *Auth) SendPasswordReset(ctx context.Context, email string) error {
+	const op = "srvc.Auth.SendPasswordReset"
+
+	user, err := a.userSrvc.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, def.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err := a.passwordResetSrvc.CreateByUser(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.mailSrvc.Send(ctx, user, def.MailPasswordReset, map[string]string{
+		def.MailKeyLink: a.passwordResetURL + "?token=" + url.QueryEscape(token),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *Auth) ResetPassword(ctx context.Context, token, password, ip string) error {
+	const op = "srvc.Auth.ResetPassword"
+
+	passwordReset, err := a.passwordResetSrvc.Consume(ctx, token)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := a.userSrvc.GetByID(ctx, passwordReset.UserID.Hex())
+	if err != nil {
+		if errors.Is(err, def.ErrNotFound) {
+			return fmt.Errorf("%s: %w", op, def.ErrInvalidResetToken)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the reset link reached the mailbox, so whoever follows it owns the
+	// address; factors set up by anyone else must not survive the reset
+	if user.EmailVerificationPending {
+		err = a.userSrvc.ConfirmEmail(ctx, user)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = a.userSrvc.ResetTwoFactor(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.setPassword(ctx, user, password, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.lockoutSrvc.Reset(ctx, user.Email)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ChangePassword counts wrong current passwords against the lockout, a
+// stolen access token must not allow guessing the password here.
+func (a *Auth) ChangePassword(ctx context.Context, user *model.User, currentPassword, newPassword, ip string) error {
+	const op = "srvc.Auth.ChangePassword"
+
+	err := a.lockoutSrvc.Check(ctx, user.Email, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = a.validateCredential(ctx, user.Email, currentPassword)
+	if err != nil {
+		if errors.Is(err, def.ErrInvalidCredentials) {
+			lockErr := a.lockoutSrvc.Fail(ctx, user.Email, ip)
+			if lockErr != nil {
+				return fmt.Errorf("%s: %w", op, lockErr)
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.setPassword(ctx, user, newPassword, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *Auth) Devices(ctx context.Context, user *model.User, refreshTokenID string) ([]dto.Device, error) {
+	const op = "srvc.Auth.Devices"
+
+	refreshTokens, err := a.refreshTokenSrvc.ListByUser(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	devices := make([]dto.Device, 0, len(refreshTokens))
+	for _, refreshToken := range refreshTokens {
+		devices = append(devices, dto.Device{
+			RefreshToken: refreshToken,
+			IsCurrent:    refreshToken.ID.Hex() == refreshTokenID,
+		})
+	}
+
+	return devices, nil
+}
+
+func (a *Auth) RevokeDevice(ctx context.Context, user *model.User, id string) error {
+	const op = "srvc.Auth.RevokeDevice"
+
+	refreshToken, err := a.refreshTokenSrvc.GetByUserAndID(ctx, user, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.refreshTokenSrvc.DeleteFamily(ctx, refreshToken)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// setPassword logs the user out everywhere, whoever knew the old password
+// must not keep a session.
+func (a *Auth) setPassword(ctx context.Context, user *model.User, password, ip string) error {
+	const op = "srvc.Auth.setPassword"
+
+	err := a.userSrvc.UpdatePassword(ctx, user, password)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.revokeAll(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.mailSrvc.Enqueue(ctx, user, def.MailPasswordChanged, map[string]string{
+		def.MailKeyIP: ip,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *Auth) revokeAll(ctx context.Context, user *model.User) error {
+	const op = "srvc.Auth.revokeAll"
+
+	err := a.refreshTokenSrvc.DeleteByUser(ctx, user)
+	if err != nil && !errors.Is(err, def.ErrNotFound) {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = a.validateRToken(ctx, user, claims.RefreshTokenID.Hex(), rToken)
+	err = a.userSrvc.RevokeTokens(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *Auth) signIn(ctx context.Context, user *model.User, ip, userAgent string) (*dto.Token, *dto.Challenge, error) {
+	const op = "srvc.Auth.signIn"
+
+	if user.TOTPEnabled {
+		challenge, err := a.createChallenge(user)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return nil, challenge, nil
+	}
+
+	token, err := a.issueToken(ctx, user, ip, userAgent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil, nil
+}
+
+// issueToken resets the account lockout only here, once every factor has
+// passed, so a correct password alone does not clear failed 2FA attempts.
+func (a *Auth) issueToken(ctx context.Context, user *model.User, ip, userAgent string) (*dto.Token, error) {
+	const op = "srvc.Auth.issueToken"
+
+	err := a.notifyNewDevice(ctx, user, ip, userAgent)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	token, err := a.createToken(ctx, user, ip)
+	token, err := a.createToken(ctx, user, primitive.NewObjectID(), ip, userAgent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = a.lockoutSrvc.Reset(ctx, user.Email)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -139,6 +581,54 @@ func (a *Auth) Refresh(ctx context.Context, aToken, rToken, ip string) (*dto.Tok
 	return token, nil
 }
 
+func (a *Auth) createChallenge(user *model.User) (*dto.Challenge, error) {
+	const op = "srvc.Auth.createChallenge"
+
+	expiresAt := time.Now().Add(time.Duration(a.challengeExpiresMinute) * time.Minute)
+	claims := jwt.RegisteredClaims{
+		Subject:   user.ID.Hex(),
+		Audience:  jwt.ClaimStrings{def.AudienceLoginChallenge.String()},
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	}
+	challengeToken, err := a.tokenSigner.Sign(claims)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &dto.Challenge{
+		ChallengeToken: challengeToken,
+		ExpiresAt:      expiresAt,
+	}, nil
+}
+
+// notifyNewDevice mails the user when none of the active refresh tokens
+// was issued to the same user agent.
+func (a *Auth) notifyNewDevice(ctx context.Context, user *model.User, ip, userAgent string) error {
+	const op = "srvc.Auth.notifyNewDevice"
+
+	refreshTokens, err := a.refreshTokenSrvc.ListByUser(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, refreshToken := range refreshTokens {
+		if refreshToken.UserAgent == userAgent {
+			return nil
+		}
+	}
+
+	err = a.mailSrvc.Enqueue(ctx, user, def.MailNewDevice, map[string]string{
+		def.MailKeyIP:        ip,
+		def.MailKeyUserAgent: userAgent,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (a *Auth) validateCredential(ctx context.Context, email, password string) (*model.User, error) {
 	const op = "srvc.Auth.validateCredential"
 
@@ -161,11 +651,16 @@ func (a *Auth) validateCredential(ctx context.Context, email, password string) (
 	return user, nil
 }
 
-func (a *Auth) createRTokenByUser(ctx context.Context, user *model.User, ip string) (*model.RefreshToken, string, error) {
+func (a *Auth) createRTokenByUser(
+	ctx context.Context,
+	user *model.User,
+	familyID primitive.ObjectID,
+	ip, userAgent string,
+) (*model.RefreshToken, string, error) {
 	const op = "srvc.Auth.createRTokenByUser"
 
-	err := a.refreshTokenSrvc.DeleteByUser(ctx, user)
-	if err != nil && !errors.Is(err, def.ErrNotFound) {
+	err := a.refreshTokenSrvc.DeleteExpiredByUser(ctx, user)
+	if err != nil {
 		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -184,7 +679,9 @@ func (a *Auth) createRTokenByUser(ctx context.Context, user *model.User, ip stri
 	refreshToken, err := a.refreshTokenSrvc.CreateByUser(
 		ctx,
 		user,
+		familyID,
 		ip,
+		userAgent,
 		string(hash),
 		time.Now().Add(time.Duration(a.rTokenExpiresHour)*time.Hour),
 	)
@@ -202,13 +699,14 @@ func (a *Auth) generateAToken(user *model.User, refreshToken *model.RefreshToken
 		IP:             ip,
 		UserID:         user.ID,
 		RefreshTokenID: refreshToken.ID,
+		TokenVersion:   user.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(a.aTokenExpiresHour) * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token, err := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString(a.jwtSecret)
+	token, err := a.tokenSigner.Sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
@@ -216,47 +714,63 @@ func (a *Auth) generateAToken(user *model.User, refreshToken *model.RefreshToken
 	return token, nil
 }
 
-func (a *Auth) getSigningKey(token *jwt.Token) (interface{}, error) {
-	const op = "srv.Auth.getSigningKey"
-
-	_, ok := token.Method.(*jwt.SigningMethodHMAC)
-	if !ok {
-		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidSigningMethod)
-	}
-
-	return a.jwtSecret, nil
-}
-
-func (a *Auth) validateRToken(ctx context.Context, user *model.User, refreshTokenID, rToken string) error {
+func (a *Auth) validateRToken(ctx context.Context, user *model.User, refreshTokenID, rToken string) (*model.RefreshToken, error) {
 	const op = "srvc.Auth.validateRToken"
 
 	refreshToken, err := a.refreshTokenSrvc.GetByUserAndID(ctx, user, refreshTokenID)
 	if err != nil {
 		if errors.Is(err, def.ErrNotFound) {
-			return fmt.Errorf("%s: %w", op, def.ErrTokensMismatch)
+			return nil, fmt.Errorf("%s: %w", op, def.ErrTokensMismatch)
 		}
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	if time.Now().After(refreshToken.ExpiresAt) {
-		return fmt.Errorf("%s: %w", op, def.ErrRTokenExpired)
+		return nil, fmt.Errorf("%s: %w", op, def.ErrRTokenExpired)
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(refreshToken.Hash), []byte(rToken))
 	if err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			return fmt.Errorf("%s: %w", op, def.ErrInvalidRToken)
+			return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidRToken)
 		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refreshToken, nil
+}
+
+// revokeFamily is called when an already rotated refresh token is presented
+// again: either the legitimate client or an attacker holds a stolen copy, so
+// every token descended from the same login is revoked.
+func (a *Auth) revokeFamily(ctx context.Context, user *model.User, refreshToken *model.RefreshToken, ip, userAgent string) error {
+	const op = "srvc.Auth.revokeFamily"
+
+	err := a.refreshTokenSrvc.DeleteFamily(ctx, refreshToken)
+	if err != nil && !errors.Is(err, def.ErrNotFound) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = a.securityEventSrvc.Create(ctx, user, def.SecurityRTokenReused, ip, userAgent, map[string]string{
+		"refresh_token_id": refreshToken.ID.Hex(),
+		"family_id":        refreshToken.FamilyID.Hex(),
+	})
+	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
 
-func (a *Auth) createToken(ctx context.Context, user *model.User, ip string) (*dto.Token, error) {
+func (a *Auth) createToken(
+	ctx context.Context,
+	user *model.User,
+	familyID primitive.ObjectID,
+	ip, userAgent string,
+) (*dto.Token, error) {
 	const op = "srvc.Auth.createToken"
 
-	refreshToken, rToken, err := a.createRTokenByUser(ctx, user, ip)
+	refreshToken, rToken, err := a.createRTokenByUser(ctx, user, familyID, ip, userAgent)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
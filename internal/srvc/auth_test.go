@@ -0,0 +1,312 @@
+package srvc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"tech_check/internal/signer"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type (
+	memoryRefreshTokenRepo struct {
+		mu     sync.Mutex
+		tokens map[primitive.ObjectID]*model.RefreshToken
+	}
+
+	memorySecurityEventRepo struct {
+		events []model.SecurityEvent
+	}
+
+	stubUserSrvc struct {
+		UserSrvc
+		user *model.User
+	}
+
+	stubMailSrvc struct {
+		templates []def.MailTemplate
+	}
+)
+
+func newMemoryRefreshTokenRepo() *memoryRefreshTokenRepo {
+	return &memoryRefreshTokenRepo{tokens: make(map[primitive.ObjectID]*model.RefreshToken)}
+}
+
+func (r *memoryRefreshTokenRepo) Create(ctx context.Context, refreshToken *model.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	refreshToken.ID = primitive.NewObjectID()
+	refreshToken.CreatedAt = time.Now()
+	stored := *refreshToken
+	r.tokens[stored.ID] = &stored
+
+	return nil
+}
+
+func (r *memoryRefreshTokenRepo) GetByUserAndID(ctx context.Context, user *model.User, id string) (*model.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, def.ErrNotFound
+	}
+	stored, ok := r.tokens[objectID]
+	if !ok || stored.UserID != user.ID {
+		return nil, def.ErrNotFound
+	}
+	refreshToken := *stored
+
+	return &refreshToken, nil
+}
+
+func (r *memoryRefreshTokenRepo) DeleteByUser(ctx context.Context, user *model.User) error {
+	return r.delete(func(t *model.RefreshToken) bool { return t.UserID == user.ID })
+}
+
+func (r *memoryRefreshTokenRepo) DeleteFamily(ctx context.Context, refreshToken *model.RefreshToken) error {
+	return r.delete(func(t *model.RefreshToken) bool {
+		return t.UserID == refreshToken.UserID && t.FamilyID == refreshToken.FamilyID
+	})
+}
+
+func (r *memoryRefreshTokenRepo) DeleteExpiredByUser(ctx context.Context, user *model.User, now time.Time) error {
+	err := r.delete(func(t *model.RefreshToken) bool { return t.UserID == user.ID && !t.ExpiresAt.After(now) })
+	if errors.Is(err, def.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (r *memoryRefreshTokenRepo) ListByUser(ctx context.Context, user *model.User, now time.Time) ([]model.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var refreshTokens []model.RefreshToken
+	for _, t := range r.tokens {
+		if t.UserID == user.ID && t.ExpiresAt.After(now) {
+			refreshTokens = append(refreshTokens, *t)
+		}
+	}
+
+	return refreshTokens, nil
+}
+
+func (r *memoryRefreshTokenRepo) MarkUsed(ctx context.Context, refreshToken *model.RefreshToken, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tokens[refreshToken.ID]
+	if !ok || stored.UsedAt != nil {
+		return def.ErrRTokenReused
+	}
+	stored.UsedAt = &now
+	refreshToken.UsedAt = &now
+
+	return nil
+}
+
+func (r *memoryRefreshTokenRepo) delete(match func(t *model.RefreshToken) bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for id, t := range r.tokens {
+		if match(t) {
+			delete(r.tokens, id)
+			deleted++
+		}
+	}
+	if deleted == 0 {
+		return def.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *memorySecurityEventRepo) Create(ctx context.Context, event *model.SecurityEvent) error {
+	event.ID = primitive.NewObjectID()
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func (u *stubUserSrvc) GetByID(ctx context.Context, id string) (*model.User, error) {
+	if id != u.user.ID.Hex() {
+		return nil, def.ErrNotFound
+	}
+	return u.user, nil
+}
+
+func (m *stubMailSrvc) Enqueue(ctx context.Context, user *model.User, template def.MailTemplate, data map[string]string) error {
+	m.templates = append(m.templates, template)
+	return nil
+}
+
+func (m *stubMailSrvc) Send(ctx context.Context, user *model.User, template def.MailTemplate, data map[string]string) error {
+	m.templates = append(m.templates, template)
+	return nil
+}
+
+type authFixture struct {
+	auth   *Auth
+	user   *model.User
+	tokens *memoryRefreshTokenRepo
+	events *memorySecurityEventRepo
+	mails  *stubMailSrvc
+}
+
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+
+	tokenSigner, err := signer.NewJWT("secret", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := authFixture{
+		user:   &model.User{ID: primitive.NewObjectID(), Email: "user@example.com"},
+		tokens: newMemoryRefreshTokenRepo(),
+		events: &memorySecurityEventRepo{},
+		mails:  &stubMailSrvc{},
+	}
+	f.auth = NewAuth(
+		"", "",
+		tokenSigner,
+		nil,
+		&stubUserSrvc{user: f.user},
+		NewRefreshToken(f.tokens),
+		NewSecurityEvent(f.events),
+		f.mails,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	return &f
+}
+
+func (f *authFixture) login(t *testing.T) (*dto.Token, *model.RefreshToken) {
+	t.Helper()
+
+	token, err := f.auth.createToken(context.Background(), f.user, primitive.NewObjectID(), "127.0.0.1", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := f.auth.DecodeAToken(context.Background(), token.AToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token, f.tokens.tokens[claims.RefreshTokenID]
+}
+
+func TestAuthRefreshRotates(t *testing.T) {
+	f := newAuthFixture(t)
+	token, refreshToken := f.login(t)
+
+	rotated, err := f.auth.Refresh(context.Background(), token.AToken, token.RToken, "127.0.0.1", "test")
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if refreshToken.UsedAt == nil {
+		t.Error("presented refresh token was not marked used")
+	}
+
+	claims, err := f.auth.DecodeAToken(context.Background(), rotated.AToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, ok := f.tokens.tokens[claims.RefreshTokenID]
+	if !ok {
+		t.Fatal("rotated refresh token was not stored")
+	}
+	if next.ID == refreshToken.ID || next.FamilyID != refreshToken.FamilyID {
+		t.Errorf("rotated token = %s in family %s, want a new token in family %s", next.ID.Hex(), next.FamilyID.Hex(), refreshToken.FamilyID.Hex())
+	}
+	if len(f.events.events) != 0 {
+		t.Errorf("security events = %d, want 0", len(f.events.events))
+	}
+}
+
+func TestAuthRefreshReuseRevokesFamily(t *testing.T) {
+	f := newAuthFixture(t)
+	token, refreshToken := f.login(t)
+	other, _ := f.login(t)
+
+	_, err := f.auth.Refresh(context.Background(), token.AToken, token.RToken, "127.0.0.1", "test")
+	if err != nil {
+		t.Fatalf("first Refresh() error = %v", err)
+	}
+
+	_, err = f.auth.Refresh(context.Background(), token.AToken, token.RToken, "127.0.0.1", "test")
+	if !errors.Is(err, def.ErrRTokenReused) {
+		t.Fatalf("second Refresh() error = %v, want %v", err, def.ErrRTokenReused)
+	}
+
+	for _, stored := range f.tokens.tokens {
+		if stored.FamilyID == refreshToken.FamilyID {
+			t.Errorf("token %s of the reused family was not revoked", stored.ID.Hex())
+		}
+	}
+	if len(f.tokens.tokens) != 1 {
+		t.Errorf("stored tokens = %d, want only the other login's", len(f.tokens.tokens))
+	}
+
+	if len(f.events.events) != 1 {
+		t.Fatalf("security events = %d, want 1", len(f.events.events))
+	}
+	event := f.events.events[0]
+	if event.Type != def.SecurityRTokenReused || event.UserID != f.user.ID {
+		t.Errorf("security event = %s for %s, want %s for %s", event.Type, event.UserID.Hex(), def.SecurityRTokenReused, f.user.ID.Hex())
+	}
+	if event.Details["family_id"] != refreshToken.FamilyID.Hex() {
+		t.Errorf("event family_id = %q, want %q", event.Details["family_id"], refreshToken.FamilyID.Hex())
+	}
+
+	_, err = f.auth.Refresh(context.Background(), other.AToken, other.RToken, "127.0.0.1", "test")
+	if err != nil {
+		t.Errorf("Refresh() of another login error = %v", err)
+	}
+}
+
+func TestAuthRefreshExpired(t *testing.T) {
+	f := newAuthFixture(t)
+	token, refreshToken := f.login(t)
+	refreshToken.ExpiresAt = time.Now().Add(-time.Minute)
+
+	_, err := f.auth.Refresh(context.Background(), token.AToken, token.RToken, "127.0.0.1", "test")
+	if !errors.Is(err, def.ErrRTokenExpired) {
+		t.Fatalf("Refresh() error = %v, want %v", err, def.ErrRTokenExpired)
+	}
+
+	if refreshToken.UsedAt != nil {
+		t.Error("expired refresh token was marked used")
+	}
+	if len(f.events.events) != 0 {
+		t.Errorf("security events = %d, want 0", len(f.events.events))
+	}
+}
+
+func TestAuthRefreshWrongToken(t *testing.T) {
+	f := newAuthFixture(t)
+	token, refreshToken := f.login(t)
+
+	_, err := f.auth.Refresh(context.Background(), token.AToken, "not the refresh token", "127.0.0.1", "test")
+	if !errors.Is(err, def.ErrInvalidRToken) {
+		t.Fatalf("Refresh() error = %v, want %v", err, def.ErrInvalidRToken)
+	}
+
+	if refreshToken.UsedAt != nil {
+		t.Error("refresh token was marked used")
+	}
+}
@@ -0,0 +1,269 @@
+package srvc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type (
+	// EffectivePermission caches the permission set of each user in
+	// process. Role changes drop every entry, user role changes drop the
+	// entry of that user, other instances catch up once their entries expire.
+	EffectivePermission struct {
+		ttl            time.Duration
+		mu             sync.RWMutex
+		generation     uint64
+		entries        map[string]effectivePermissionEntry
+		roleRepo       RoleRepo
+		permissionRepo PermissionRepo
+	}
+
+	effectivePermissionEntry struct {
+		permissions *dto.EffectivePermissions
+		expiresAt   time.Time
+	}
+
+	roleGraph struct {
+		roles     map[primitive.ObjectID]model.Role
+		slugNames map[primitive.ObjectID]string
+	}
+)
+
+func NewEffectivePermission(
+	ttlSecond int,
+	roleRepo RoleRepo,
+	permissionRepo PermissionRepo,
+) *EffectivePermission {
+	return &EffectivePermission{
+		ttl:            time.Duration(ttlSecond) * time.Second,
+		entries:        make(map[string]effectivePermissionEntry),
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+	}
+}
+
+// Get returns a shared value, callers must not modify it.
+func (e *EffectivePermission) Get(ctx context.Context, user *model.User) (*dto.EffectivePermissions, error) {
+	const op = "srvc.EffectivePermission.Get"
+
+	userID := user.ID.Hex()
+
+	e.mu.RLock()
+	entry, ok := e.entries[userID]
+	generation := e.generation
+	e.mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.permissions, nil
+	}
+
+	permissions, err := e.resolve(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// an invalidation while resolving means the result may already be stale
+	if generation != e.generation {
+		return permissions, nil
+	}
+
+	now := time.Now()
+	for id, entry := range e.entries {
+		if now.After(entry.expiresAt) {
+			delete(e.entries, id)
+		}
+	}
+
+	e.entries[userID] = effectivePermissionEntry{
+		permissions: permissions,
+		expiresAt:   now.Add(e.ttl),
+	}
+
+	return permissions, nil
+}
+
+func (e *EffectivePermission) Invalidate(userID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.generation++
+	delete(e.entries, userID)
+}
+
+func (e *EffectivePermission) InvalidateAll() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.generation++
+	e.entries = make(map[string]effectivePermissionEntry)
+}
+
+// Expand resolves the role together with the roles it inherits from, the
+// returned slugs have their wildcards expanded to registered slugs.
+func (e *EffectivePermission) Expand(ctx context.Context, role *model.Role) (*dto.RolePermissions, error) {
+	const op = "srvc.EffectivePermission.Expand"
+
+	graph, err := e.load(ctx, []primitive.ObjectID{role.ID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roleIDs := []primitive.ObjectID{}
+	var granted []string
+	for _, roleID := range graph.closure(role.ID) {
+		if roleID != role.ID {
+			roleIDs = append(roleIDs, roleID)
+		}
+		granted = append(granted, graph.slugs(roleID)...)
+	}
+
+	return &dto.RolePermissions{
+		InheritedRoleIDs: roleIDs,
+		Slugs:            expandSlugs(granted),
+	}, nil
+}
+
+// resolve collects the slugs of every role the user holds, including the
+// roles they inherit from. Wildcard slugs are kept as granted.
+func (e *EffectivePermission) resolve(ctx context.Context, user *model.User) (*dto.EffectivePermissions, error) {
+	const op = "srvc.EffectivePermission.resolve"
+
+	permissions := dto.EffectivePermissions{
+		Slugs:  []string{},
+		Scoped: map[string][]string{},
+	}
+
+	roleIDs := append([]primitive.ObjectID{}, user.RoleIDs...)
+	for _, scopedRole := range user.ScopedRoles {
+		roleIDs = append(roleIDs, scopedRole.RoleID)
+	}
+	if len(roleIDs) == 0 {
+		return &permissions, nil
+	}
+
+	graph, err := e.load(ctx, roleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	isGlobal := make(map[string]bool)
+	for _, roleID := range user.RoleIDs {
+		for _, closureID := range graph.closure(roleID) {
+			if graph.roles[closureID].RequireTOTP {
+				permissions.RequiresTOTP = true
+			}
+			for _, slug := range graph.slugs(closureID) {
+				if !isGlobal[slug] {
+					isGlobal[slug] = true
+					permissions.Slugs = append(permissions.Slugs, slug)
+				}
+			}
+		}
+	}
+
+	isScoped := make(map[string]bool)
+	for _, scopedRole := range user.ScopedRoles {
+		categoryID := scopedRole.CategoryID.Hex()
+		for _, closureID := range graph.closure(scopedRole.RoleID) {
+			if graph.roles[closureID].RequireTOTP {
+				permissions.RequiresTOTP = true
+			}
+			for _, slug := range graph.slugs(closureID) {
+				if isGlobal[slug] || isScoped[slug+"/"+categoryID] {
+					continue
+				}
+				isScoped[slug+"/"+categoryID] = true
+				permissions.Scoped[slug] = append(permissions.Scoped[slug], categoryID)
+			}
+		}
+	}
+
+	return &permissions, nil
+}
+
+func (e *EffectivePermission) load(ctx context.Context, roleIDs []primitive.ObjectID) (*roleGraph, error) {
+	const op = "srvc.EffectivePermission.load"
+
+	roles, err := e.roleRepo.ListWithAncestors(ctx, roleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	graph := roleGraph{
+		roles:     make(map[primitive.ObjectID]model.Role, len(roles)),
+		slugNames: make(map[primitive.ObjectID]string),
+	}
+
+	var permissionIDs []primitive.ObjectID
+	for _, role := range roles {
+		graph.roles[role.ID] = role
+		permissionIDs = append(permissionIDs, role.PermissionIDs...)
+	}
+
+	permissionList, err := e.permissionRepo.ListByIDs(ctx, permissionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, permission := range permissionList {
+		graph.slugNames[permission.ID] = permission.Slug
+	}
+
+	return &graph, nil
+}
+
+func (g *roleGraph) closure(roleID primitive.ObjectID) []primitive.ObjectID {
+	var roleIDs []primitive.ObjectID
+	visited := make(map[primitive.ObjectID]bool)
+	pending := []primitive.ObjectID{roleID}
+	for len(pending) > 0 {
+		id := pending[0]
+		pending = pending[1:]
+
+		role, ok := g.roles[id]
+		if !ok || visited[id] {
+			continue
+		}
+		visited[id] = true
+		roleIDs = append(roleIDs, id)
+		pending = append(pending, role.ParentIDs...)
+	}
+
+	return roleIDs
+}
+
+func (g *roleGraph) slugs(roleID primitive.ObjectID) []string {
+	var slugs []string
+	for _, permissionID := range g.roles[roleID].PermissionIDs {
+		slug, ok := g.slugNames[permissionID]
+		if ok {
+			slugs = append(slugs, slug)
+		}
+	}
+
+	return slugs
+}
+
+// expandSlugs replaces wildcard slugs with the registered slugs they cover.
+func expandSlugs(granted []string) []string {
+	slugs := []string{}
+	isAdded := make(map[def.PermissionSlug]bool)
+	for _, grantedSlug := range granted {
+		for _, slug := range def.PermissionSlug(grantedSlug).Expand() {
+			if !isAdded[slug] {
+				isAdded[slug] = true
+				slugs = append(slugs, slug.String())
+			}
+		}
+	}
+
+	return slugs
+}
@@ -0,0 +1,102 @@
+package srvc
+
+import (
+	"context"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/model"
+	"time"
+)
+
+type Job struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	lockTimeout time.Duration
+	jobRepo     JobRepo
+}
+
+func NewJob(jobRepo JobRepo) *Job {
+	return &Job{
+		maxAttempts: 8,
+		baseDelay:   2 * time.Second,
+		maxDelay:    5 * time.Minute,
+		lockTimeout: 10 * time.Minute,
+		jobRepo:     jobRepo,
+	}
+}
+
+func (j *Job) Enqueue(ctx context.Context, jobType def.JobType, payload map[string]string) (*model.Job, error) {
+	const op = "srvc.Job.Enqueue"
+
+	job := model.Job{
+		Type:        jobType,
+		Payload:     payload,
+		Status:      def.JobPending,
+		MaxAttempts: j.maxAttempts,
+		RunAt:       time.Now(),
+	}
+	err := j.jobRepo.Create(ctx, &job)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &job, nil
+}
+
+func (j *Job) Claim(ctx context.Context) (*model.Job, error) {
+	const op = "srvc.Job.Claim"
+
+	now := time.Now()
+	job, err := j.jobRepo.ClaimNext(ctx, now, now.Add(-j.lockTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return job, nil
+}
+
+func (j *Job) Complete(ctx context.Context, job *model.Job) error {
+	const op = "srvc.Job.Complete"
+
+	job.Status = def.JobDone
+	job.LastError = ""
+	job.LockedAt = nil
+	err := j.jobRepo.Update(ctx, job)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (j *Job) Fail(ctx context.Context, job *model.Job, cause error) (bool, error) {
+	const op = "srvc.Job.Fail"
+
+	isDead := job.Attempts >= job.MaxAttempts
+
+	job.LastError = cause.Error()
+	job.LockedAt = nil
+	if isDead {
+		job.Status = def.JobDead
+	} else {
+		job.Status = def.JobPending
+		job.RunAt = time.Now().Add(j.backoff(job.Attempts))
+	}
+
+	err := j.jobRepo.Update(ctx, job)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return isDead, nil
+}
+
+func (j *Job) backoff(attempts int) time.Duration {
+	delay := j.baseDelay
+	for i := 1; i < attempts && delay < j.maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, j.maxDelay)
+}
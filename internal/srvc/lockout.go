@@ -0,0 +1,214 @@
+package srvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"time"
+)
+
+type Lockout struct {
+	accountThreshold int
+	ipThreshold      int
+	baseDuration     time.Duration
+	maxDuration      time.Duration
+	resetAfter       time.Duration
+	lockoutRepo      LockoutRepo
+}
+
+func NewLockout(
+	accountThreshold, ipThreshold, baseSecond, maxMinute, resetHour int,
+	lockoutRepo LockoutRepo,
+) *Lockout {
+	return &Lockout{
+		accountThreshold: accountThreshold,
+		ipThreshold:      ipThreshold,
+		baseDuration:     time.Duration(baseSecond) * time.Second,
+		maxDuration:      time.Duration(maxMinute) * time.Minute,
+		resetAfter:       time.Duration(resetHour) * time.Hour,
+		lockoutRepo:      lockoutRepo,
+	}
+}
+
+func (l *Lockout) List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.Lockout, *dto.Pagination, error) {
+	const op = "srvc.Lockout.List"
+
+	lockouts, pagination, err := l.lockoutRepo.List(ctx, page, count, filters, sorts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lockouts, pagination, nil
+}
+
+func (l *Lockout) GetByID(ctx context.Context, id string) (*model.Lockout, error) {
+	const op = "srvc.Lockout.GetByID"
+
+	lockout, err := l.lockoutRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lockout, nil
+}
+
+func (l *Lockout) Delete(ctx context.Context, id string) error {
+	const op = "srvc.Lockout.Delete"
+
+	err := l.lockoutRepo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Check runs before the password is compared, so a locked account or ip
+// does not cost a bcrypt comparison.
+func (l *Lockout) Check(ctx context.Context, email, ip string) error {
+	const op = "srvc.Lockout.Check"
+
+	err := l.check(ctx, l.keys(email, ip))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (l *Lockout) Fail(ctx context.Context, email, ip string) error {
+	const op = "srvc.Lockout.Fail"
+
+	err := l.fail(ctx, l.keys(email, ip))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CheckPasswordReset and FailPasswordReset throttle password reset requests
+// on counters of their own, anyone can request a reset for any email and
+// must not be able to lock its owner out of login that way.
+func (l *Lockout) CheckPasswordReset(ctx context.Context, email, ip string) error {
+	const op = "srvc.Lockout.CheckPasswordReset"
+
+	err := l.check(ctx, l.passwordResetKeys(email, ip))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (l *Lockout) FailPasswordReset(ctx context.Context, email, ip string) error {
+	const op = "srvc.Lockout.FailPasswordReset"
+
+	err := l.fail(ctx, l.passwordResetKeys(email, ip))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Reset clears the account counter after a successful login, the ip counter
+// is left to expire so one valid account cannot shield guessing on others.
+func (l *Lockout) Reset(ctx context.Context, email string) error {
+	const op = "srvc.Lockout.Reset"
+
+	err := l.lockoutRepo.DeleteByKey(ctx, def.LockoutAccount, l.normalize(email))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (l *Lockout) check(ctx context.Context, keys map[def.LockoutKind]string) error {
+	now := time.Now()
+	for kind, key := range keys {
+		lockout, err := l.lockoutRepo.GetByKey(ctx, kind, key)
+		if err != nil {
+			if errors.Is(err, def.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+
+		if lockout.LockedUntil != nil && lockout.LockedUntil.After(now) {
+			return &def.TooManyAttemptsError{RetryAfter: lockout.LockedUntil.Sub(now)}
+		}
+	}
+
+	return nil
+}
+
+func (l *Lockout) fail(ctx context.Context, keys map[def.LockoutKind]string) error {
+	now := time.Now()
+	for kind, key := range keys {
+		lockout, err := l.lockoutRepo.IncFailures(ctx, kind, key, now, now.Add(-l.resetAfter))
+		if err != nil {
+			return err
+		}
+
+		threshold := l.accountThreshold
+		if kind == def.LockoutIP || kind == def.LockoutPasswordResetIP {
+			threshold = l.ipThreshold
+		}
+		if lockout.Failures < threshold {
+			continue
+		}
+
+		err = l.lockoutRepo.SetLockedUntil(ctx, lockout, now.Add(l.lockDuration(lockout.Failures-threshold)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Lockout) keys(email, ip string) map[def.LockoutKind]string {
+	keys := map[def.LockoutKind]string{
+		def.LockoutAccount: l.normalize(email),
+	}
+	if ip != "" {
+		keys[def.LockoutIP] = ip
+	}
+
+	return keys
+}
+
+func (l *Lockout) passwordResetKeys(email, ip string) map[def.LockoutKind]string {
+	keys := map[def.LockoutKind]string{
+		def.LockoutPasswordResetAccount: l.normalize(email),
+	}
+	if ip != "" {
+		keys[def.LockoutPasswordResetIP] = ip
+	}
+
+	return keys
+}
+
+func (l *Lockout) normalize(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// lockDuration doubles the base duration for every failure past the threshold.
+func (l *Lockout) lockDuration(excess int) time.Duration {
+	if excess >= 32 {
+		return l.maxDuration
+	}
+
+	duration := l.baseDuration << excess
+	if duration <= 0 || duration > l.maxDuration {
+		return l.maxDuration
+	}
+
+	return duration
+}
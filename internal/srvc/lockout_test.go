@@ -0,0 +1,287 @@
+package srvc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type (
+	lockoutKey struct {
+		kind def.LockoutKind
+		key  string
+	}
+
+	memoryLockoutRepo struct {
+		mu       sync.Mutex
+		lockouts map[lockoutKey]*model.Lockout
+	}
+)
+
+func newMemoryLockoutRepo() *memoryLockoutRepo {
+	return &memoryLockoutRepo{lockouts: make(map[lockoutKey]*model.Lockout)}
+}
+
+func (r *memoryLockoutRepo) List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.Lockout, *dto.Pagination, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+func (r *memoryLockoutRepo) GetByID(ctx context.Context, id string) (*model.Lockout, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *memoryLockoutRepo) Delete(ctx context.Context, id string) error {
+	return errors.New("not implemented")
+}
+
+func (r *memoryLockoutRepo) GetByKey(ctx context.Context, kind def.LockoutKind, key string) (*model.Lockout, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.lockouts[lockoutKey{kind, key}]
+	if !ok {
+		return nil, def.ErrNotFound
+	}
+	lockout := *stored
+
+	return &lockout, nil
+}
+
+func (r *memoryLockoutRepo) IncFailures(ctx context.Context, kind def.LockoutKind, key string, now, resetBefore time.Time) (*model.Lockout, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.lockouts[lockoutKey{kind, key}]
+	if !ok {
+		stored = &model.Lockout{ID: primitive.NewObjectID(), Kind: kind, Key: key, CreatedAt: now}
+		r.lockouts[lockoutKey{kind, key}] = stored
+	}
+	if stored.UpdatedAt.Before(resetBefore) {
+		stored.Failures = 1
+	} else {
+		stored.Failures++
+	}
+	stored.UpdatedAt = now
+	lockout := *stored
+
+	return &lockout, nil
+}
+
+func (r *memoryLockoutRepo) SetLockedUntil(ctx context.Context, lockout *model.Lockout, lockedUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lockout.LockedUntil = &lockedUntil
+	r.lockouts[lockoutKey{lockout.Kind, lockout.Key}].LockedUntil = &lockedUntil
+
+	return nil
+}
+
+func (r *memoryLockoutRepo) DeleteByKey(ctx context.Context, kind def.LockoutKind, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.lockouts, lockoutKey{kind, key})
+
+	return nil
+}
+
+func (r *memoryLockoutRepo) get(kind def.LockoutKind, key string) *model.Lockout {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lockouts[lockoutKey{kind, key}]
+}
+
+func TestLockoutLockDuration(t *testing.T) {
+	l := NewLockout(5, 20, 30, 60, 24, nil)
+
+	tests := []struct {
+		excess int
+		want   time.Duration
+	}{
+		{excess: 0, want: 30 * time.Second},
+		{excess: 1, want: time.Minute},
+		{excess: 3, want: 4 * time.Minute},
+		{excess: 6, want: 32 * time.Minute},
+		{excess: 7, want: time.Hour},
+		{excess: 31, want: time.Hour},
+		{excess: 32, want: time.Hour},
+		{excess: 1000, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		got := l.lockDuration(tt.excess)
+		if got != tt.want {
+			t.Errorf("lockDuration(%d) = %s, want %s", tt.excess, got, tt.want)
+		}
+	}
+}
+
+func TestLockoutFail(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryLockoutRepo()
+	l := NewLockout(3, 5, 30, 60, 24, repo)
+
+	for attempt := 1; attempt < 3; attempt++ {
+		err := l.Fail(ctx, "User@Example.com ", "10.0.0.1")
+		if err != nil {
+			t.Fatalf("Fail() error = %v", err)
+		}
+	}
+	err := l.Check(ctx, "user@example.com", "10.0.0.1")
+	if err != nil {
+		t.Fatalf("Check() below the threshold error = %v", err)
+	}
+
+	err = l.Fail(ctx, "user@example.com", "10.0.0.1")
+	if err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+
+	account := repo.get(def.LockoutAccount, "user@example.com")
+	if account == nil || account.Failures != 3 || account.LockedUntil == nil {
+		t.Fatalf("account lockout = %+v, want locked after 3 failures", account)
+	}
+	ip := repo.get(def.LockoutIP, "10.0.0.1")
+	if ip == nil || ip.Failures != 3 || ip.LockedUntil != nil {
+		t.Fatalf("ip lockout = %+v, want 3 failures below the ip threshold", ip)
+	}
+
+	var tooMany *def.TooManyAttemptsError
+	err = l.Check(ctx, "user@example.com", "10.0.0.2")
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("Check() error = %v, want %T", err, tooMany)
+	}
+	if tooMany.RetryAfter <= 0 || tooMany.RetryAfter > 30*time.Second {
+		t.Errorf("RetryAfter = %s, want at most the base duration", tooMany.RetryAfter)
+	}
+
+	err = l.Check(ctx, "other@example.com", "10.0.0.1")
+	if err != nil {
+		t.Errorf("Check() of another account below the ip threshold error = %v", err)
+	}
+
+	err = l.Reset(ctx, "user@example.com")
+	if err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	err = l.Check(ctx, "user@example.com", "10.0.0.1")
+	if err != nil {
+		t.Errorf("Check() after Reset() error = %v", err)
+	}
+	if repo.get(def.LockoutIP, "10.0.0.1") == nil {
+		t.Error("Reset() cleared the ip counter")
+	}
+}
+
+func TestLockoutFailIPThreshold(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryLockoutRepo()
+	l := NewLockout(3, 5, 30, 60, 24, repo)
+
+	for idx := 0; idx < 5; idx++ {
+		email := string(rune('a'+idx)) + "@example.com"
+		err := l.Fail(ctx, email, "10.0.0.1")
+		if err != nil {
+			t.Fatalf("Fail() error = %v", err)
+		}
+	}
+
+	var tooMany *def.TooManyAttemptsError
+	err := l.Check(ctx, "z@example.com", "10.0.0.1")
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("Check() error = %v, want %T", err, tooMany)
+	}
+}
+
+func TestLockoutFailDoublesDuration(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryLockoutRepo()
+	l := NewLockout(3, 100, 30, 60, 24, repo)
+
+	var previous time.Time
+	for attempt := 1; attempt <= 5; attempt++ {
+		err := l.Fail(ctx, "user@example.com", "")
+		if err != nil {
+			t.Fatalf("Fail() error = %v", err)
+		}
+
+		account := repo.get(def.LockoutAccount, "user@example.com")
+		if attempt < 3 {
+			if account.LockedUntil != nil {
+				t.Fatalf("attempt %d locked the account below the threshold", attempt)
+			}
+			continue
+		}
+
+		lockedFor := account.LockedUntil.Sub(account.UpdatedAt)
+		want := 30 * time.Second << (attempt - 3)
+		if lockedFor != want {
+			t.Errorf("attempt %d locked for %s, want %s", attempt, lockedFor, want)
+		}
+		if !account.LockedUntil.After(previous) {
+			t.Errorf("attempt %d did not extend the lock", attempt)
+		}
+		previous = *account.LockedUntil
+	}
+
+	if repo.get(def.LockoutIP, "") != nil {
+		t.Error("Fail() without an ip counted an empty ip")
+	}
+}
+
+func TestLockoutFailStartsOverAfterReset(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryLockoutRepo()
+	l := NewLockout(3, 5, 30, 60, 24, repo)
+
+	for attempt := 0; attempt < 2; attempt++ {
+		err := l.Fail(ctx, "user@example.com", "")
+		if err != nil {
+			t.Fatalf("Fail() error = %v", err)
+		}
+	}
+	repo.get(def.LockoutAccount, "user@example.com").UpdatedAt = time.Now().Add(-25 * time.Hour)
+
+	err := l.Fail(ctx, "user@example.com", "")
+	if err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+
+	account := repo.get(def.LockoutAccount, "user@example.com")
+	if account.Failures != 1 || account.LockedUntil != nil {
+		t.Errorf("account lockout = %+v, want the counter started over", account)
+	}
+}
+
+func TestLockoutPasswordResetIsSeparate(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryLockoutRepo()
+	l := NewLockout(3, 5, 30, 60, 24, repo)
+
+	for attempt := 0; attempt < 10; attempt++ {
+		err := l.FailPasswordReset(ctx, "user@example.com", "10.0.0.1")
+		if err != nil {
+			t.Fatalf("FailPasswordReset() error = %v", err)
+		}
+	}
+
+	var tooMany *def.TooManyAttemptsError
+	err := l.CheckPasswordReset(ctx, "user@example.com", "10.0.0.1")
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("CheckPasswordReset() error = %v, want %T", err, tooMany)
+	}
+
+	err = l.Check(ctx, "user@example.com", "10.0.0.2")
+	if err != nil {
+		t.Errorf("Check() after password reset requests error = %v", err)
+	}
+}
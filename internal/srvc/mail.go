@@ -0,0 +1,93 @@
+package srvc
+
+import (
+	"context"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/model"
+	"time"
+)
+
+type Mail struct {
+	jobSrvc      JobSrvc
+	mailRenderer MailRenderer
+	mailer       Mailer
+}
+
+func NewMail(jobSrvc JobSrvc, mailRenderer MailRenderer, mailer Mailer) *Mail {
+	return &Mail{
+		jobSrvc:      jobSrvc,
+		mailRenderer: mailRenderer,
+		mailer:       mailer,
+	}
+}
+
+// Enqueue schedules the mail as a job so that a slow or unavailable mail
+// server never delays the request that triggered it.
+func (m *Mail) Enqueue(ctx context.Context, user *model.User, template def.MailTemplate, data map[string]string) error {
+	const op = "srvc.Mail.Enqueue"
+
+	payload := m.data(user, data)
+	payload[def.JobKeyMailTo] = user.Email
+	payload[def.JobKeyMailTemplate] = template.String()
+
+	_, err := m.jobSrvc.Enqueue(ctx, def.JobSendMail, payload)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Send delivers the mail right away, for callers that already run in a job
+// and whose data must not be stored in a job payload, such as reset links.
+func (m *Mail) Send(ctx context.Context, user *model.User, template def.MailTemplate, data map[string]string) error {
+	const op = "srvc.Mail.Send"
+
+	err := m.send(ctx, template, user.Email, m.data(user, data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (m *Mail) Deliver(ctx context.Context, payload map[string]string) error {
+	const op = "srvc.Mail.Deliver"
+
+	data := make(map[string]string, len(payload))
+	for key, value := range payload {
+		if key == def.JobKeyMailTo || key == def.JobKeyMailTemplate {
+			continue
+		}
+		data[key] = value
+	}
+
+	err := m.send(ctx, def.MailTemplate(payload[def.JobKeyMailTemplate]), payload[def.JobKeyMailTo], data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (m *Mail) data(user *model.User, data map[string]string) map[string]string {
+	merged := map[string]string{
+		def.MailKeyName: user.Name,
+		def.MailKeyTime: time.Now().UTC().Format("2006-01-02 15:04 MST"),
+	}
+	for key, value := range data {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func (m *Mail) send(ctx context.Context, template def.MailTemplate, to string, data map[string]string) error {
+	mail, err := m.mailRenderer.Render(template, to, data)
+	if err != nil {
+		return err
+	}
+
+	return m.mailer.Send(ctx, mail)
+}
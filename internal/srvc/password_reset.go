@@ -0,0 +1,79 @@
+package srvc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/model"
+	"time"
+)
+
+type PasswordReset struct {
+	ttl               time.Duration
+	tokenLength       int
+	passwordResetRepo PasswordResetRepo
+}
+
+func NewPasswordReset(ttlMinute int, passwordResetRepo PasswordResetRepo) *PasswordReset {
+	return &PasswordReset{
+		ttl:               time.Duration(ttlMinute) * time.Minute,
+		tokenLength:       32,
+		passwordResetRepo: passwordResetRepo,
+	}
+}
+
+// CreateByUser replaces any pending reset of the user and returns the plain
+// token, only its hash is stored.
+func (p *PasswordReset) CreateByUser(ctx context.Context, user *model.User) (string, error) {
+	const op = "srvc.PasswordReset.CreateByUser"
+
+	err := p.passwordResetRepo.DeleteByUser(ctx, user)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	random := make([]byte, p.tokenLength)
+	_, err = rand.Read(random)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(random)
+
+	passwordReset := model.PasswordReset{
+		UserID:    user.ID,
+		Hash:      p.hash(token),
+		ExpiresAt: time.Now().Add(p.ttl),
+	}
+	err = p.passwordResetRepo.Create(ctx, &passwordReset)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+func (p *PasswordReset) Consume(ctx context.Context, token string) (*model.PasswordReset, error) {
+	const op = "srvc.PasswordReset.Consume"
+
+	passwordReset, err := p.passwordResetRepo.Consume(ctx, p.hash(token), time.Now())
+	if err != nil {
+		if errors.Is(err, def.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidResetToken)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return passwordReset, nil
+}
+
+// hash uses sha256 rather than bcrypt: the token is random and long enough
+// that it cannot be brute forced, and a deterministic hash allows lookup.
+func (p *PasswordReset) hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -3,6 +3,7 @@ package srvc
 import (
 	"context"
 	"fmt"
+	"tech_check/internal/def"
 	"tech_check/internal/dto"
 	"tech_check/internal/model"
 )
@@ -40,3 +41,37 @@ func (p *Permission) GetByID(ctx context.Context, id string) (*model.Permission,
 
 	return permission, nil
 }
+
+// Sync creates every permission from the def registry that is missing in
+// the database and reports stored slugs the registry no longer knows.
+func (p *Permission) Sync(ctx context.Context) (*dto.PermissionSync, error) {
+	const op = "srvc.Permission.Sync"
+
+	sync := dto.PermissionSync{}
+	for _, slug := range def.PermissionSlugs() {
+		permission := model.Permission{
+			Name: slug.Name(),
+			Slug: slug.String(),
+		}
+
+		isCreated, err := p.permissionRepo.CreateIfMissing(ctx, &permission)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if isCreated {
+			sync.Created = append(sync.Created, permission.Slug)
+		}
+	}
+
+	slugs, err := p.permissionRepo.ListSlugs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, slug := range slugs {
+		if !def.PermissionSlug(slug).IsRegistered() {
+			sync.Orphaned = append(sync.Orphaned, slug)
+		}
+	}
+
+	return &sync, nil
+}
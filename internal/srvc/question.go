@@ -6,20 +6,28 @@ import (
 	"tech_check/internal/def"
 	"tech_check/internal/dto"
 	"tech_check/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Question struct {
-	questionRepo QuestionRepo
-	categorySrvc CategorySrvc
+	questionRepo        QuestionRepo
+	categorySrvc        CategorySrvc
+	sessionQuestionSrvc SessionQuestionSrvc
+	auditLogSrvc        AuditLogSrvc
 }
 
 func NewQuestion(
 	questionRepo QuestionRepo,
 	categorySrvc CategorySrvc,
+	sessionQuestionSrvc SessionQuestionSrvc,
+	auditLogSrvc AuditLogSrvc,
 ) *Question {
 	return &Question{
-		questionRepo: questionRepo,
-		categorySrvc: categorySrvc,
+		questionRepo:        questionRepo,
+		categorySrvc:        categorySrvc,
+		sessionQuestionSrvc: sessionQuestionSrvc,
+		auditLogSrvc:        auditLogSrvc,
 	}
 }
 
@@ -34,7 +42,13 @@ func (q *Question) List(ctx context.Context, page, count int, filters, sorts map
 	return questions, pagination, nil
 }
 
-func (q *Question) Create(ctx context.Context, text, grade, categoryID string) (*model.Question, error) {
+func (q *Question) Create(
+	ctx context.Context,
+	text, grade, categoryID, questionType string,
+	options []model.QuestionOption,
+	code model.QuestionCode,
+	rubric model.QuestionRubric,
+) (*model.Question, error) {
 	const op = "srvq.Question.Create"
 
 	gradeObj, err := def.ValidateGradeName(grade)
@@ -42,6 +56,21 @@ func (q *Question) Create(ctx context.Context, text, grade, categoryID string) (
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	typeObj, err := def.ValidateQuestionType(questionType)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	options, err = q.toOptions(typeObj, options, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	code, err = q.toCode(typeObj, code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	category, err := q.categorySrvc.GetByID(ctx, categoryID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -51,12 +80,18 @@ func (q *Question) Create(ctx context.Context, text, grade, categoryID string) (
 		Text:       text,
 		Grade:      gradeObj,
 		CategoryID: category.ID,
+		Type:       typeObj,
+		Options:    options,
+		Code:       code,
+		Rubric:     rubric,
 	}
 	err = q.questionRepo.Create(ctx, &question)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	q.auditLogSrvc.Record(ctx, def.AuditQuestionCreate, def.AuditTargetQuestion, question.ID, nil, &question)
+
 	return &question, nil
 }
 
@@ -71,7 +106,13 @@ func (q *Question) GetByID(ctx context.Context, id string) (*model.Question, err
 	return question, nil
 }
 
-func (q *Question) Update(ctx context.Context, id, text, grade string) (*model.Question, error) {
+func (q *Question) Update(
+	ctx context.Context,
+	id, text, grade, questionType string,
+	options []model.QuestionOption,
+	code model.QuestionCode,
+	rubric model.QuestionRubric,
+) (*model.Question, error) {
 	const op = "srvq.Question.Update"
 
 	gradeObj, err := def.ValidateGradeName(grade)
@@ -84,28 +125,92 @@ func (q *Question) Update(ctx context.Context, id, text, grade string) (*model.Q
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	// an omitted type keeps the current one instead of falling back to text
+	typeObj := question.Type
+	if questionType != "" {
+		typeObj, err = def.ValidateQuestionType(questionType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	options, err = q.toOptions(typeObj, options, question.Options)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	code, err = q.toCode(typeObj, code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	before, err := auditSnapshot(question)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	question.Text = text
 	question.Grade = gradeObj
+	question.Type = typeObj
+	question.Options = options
+	question.Code = code
+	question.Rubric = rubric
 	err = q.questionRepo.Update(ctx, question)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	q.auditLogSrvc.Record(ctx, def.AuditQuestionUpdate, def.AuditTargetQuestion, question.ID, before, question)
+
 	return question, nil
 }
 
 func (q *Question) Delete(ctx context.Context, id string) error {
 	const op = "srvc.Question.Delete"
 
-	err := q.questionRepo.Delete(ctx, id)
+	question, err := q.questionRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	before, err := auditSnapshot(question)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	err = q.questionRepo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	q.auditLogSrvc.Record(ctx, def.AuditQuestionDelete, def.AuditTargetQuestion, question.ID, before, nil)
+
 	return nil
 }
 
-func (q *Question) GetRandom(ctx context.Context, category *model.Category, grade string, count int) ([]model.Question, error) {
+func (q *Question) GetStats(ctx context.Context, id string) (*dto.QuestionStats, error) {
+	const op = "srvc.Question.GetStats"
+
+	question, err := q.questionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stats, err := q.sessionQuestionSrvc.StatsByQuestion(ctx, question)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stats, nil
+}
+
+func (q *Question) GetRandom(
+	ctx context.Context,
+	category *model.Category,
+	grade string,
+	count int,
+	excludeIDs []primitive.ObjectID,
+) ([]model.Question, error) {
 	const op = "srvc.Question.GetRandom"
 
 	gradeObj, err := def.ValidateGradeName(grade)
@@ -113,10 +218,63 @@ func (q *Question) GetRandom(ctx context.Context, category *model.Category, grad
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	questions, err := q.questionRepo.GetRandom(ctx, category, gradeObj, count)
+	questions, err := q.questionRepo.GetRandom(ctx, category, gradeObj, count, excludeIDs)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return questions, nil
 }
+
+func (q *Question) toOptions(questionType def.QuestionType, options, existing []model.QuestionOption) ([]model.QuestionOption, error) {
+	if !questionType.IsChoice() {
+		if len(options) > 0 {
+			return nil, def.ErrInvalidOptions
+		}
+		return []model.QuestionOption{}, nil
+	}
+
+	if len(options) < 2 {
+		return nil, def.ErrInvalidOptions
+	}
+
+	existingIDs := make(map[string]bool, len(existing))
+	for _, option := range existing {
+		existingIDs[option.ID] = true
+	}
+
+	correct := 0
+	result := make([]model.QuestionOption, 0, len(options))
+	for _, option := range options {
+		if existingIDs[option.ID] {
+			delete(existingIDs, option.ID)
+		} else {
+			option.ID = primitive.NewObjectID().Hex()
+		}
+		if option.IsCorrect {
+			correct++
+		}
+		result = append(result, option)
+	}
+
+	if correct == 0 || (questionType == def.QuestionSingleChoice && correct != 1) {
+		return nil, def.ErrInvalidOptions
+	}
+
+	return result, nil
+}
+
+func (q *Question) toCode(questionType def.QuestionType, code model.QuestionCode) (model.QuestionCode, error) {
+	if questionType != def.QuestionCode {
+		if code.Stub != "" || len(code.Tests) > 0 {
+			return model.QuestionCode{}, def.ErrInvalidCode
+		}
+		return model.QuestionCode{Tests: []model.QuestionTest{}}, nil
+	}
+
+	if len(code.Tests) == 0 {
+		return model.QuestionCode{}, def.ErrInvalidCode
+	}
+
+	return code, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"tech_check/internal/model"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type RefreshToken struct {
@@ -28,12 +30,42 @@ func (r *RefreshToken) DeleteByUser(ctx context.Context, user *model.User) error
 	return nil
 }
 
-func (r *RefreshToken) CreateByUser(ctx context.Context, user *model.User, ip, hash string, expiresAt time.Time) (*model.RefreshToken, error) {
+func (r *RefreshToken) DeleteFamily(ctx context.Context, refreshToken *model.RefreshToken) error {
+	const op = "srvc.RefreshToken.DeleteFamily"
+
+	err := r.refreshTokenRepo.DeleteFamily(ctx, refreshToken)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *RefreshToken) DeleteExpiredByUser(ctx context.Context, user *model.User) error {
+	const op = "srvc.RefreshToken.DeleteExpiredByUser"
+
+	err := r.refreshTokenRepo.DeleteExpiredByUser(ctx, user, time.Now())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *RefreshToken) CreateByUser(
+	ctx context.Context,
+	user *model.User,
+	familyID primitive.ObjectID,
+	ip, userAgent, hash string,
+	expiresAt time.Time,
+) (*model.RefreshToken, error) {
 	const op = "srvc.RefreshToken.CreateByUser"
 
 	refreshToken := model.RefreshToken{
 		UserID:    user.ID,
+		FamilyID:  familyID,
 		IP:        ip,
+		UserAgent: userAgent,
 		Hash:      hash,
 		ExpiresAt: expiresAt,
 	}
@@ -55,3 +87,25 @@ func (r *RefreshToken) GetByUserAndID(ctx context.Context, user *model.User, id
 
 	return refreshToken, nil
 }
+
+func (r *RefreshToken) ListByUser(ctx context.Context, user *model.User) ([]model.RefreshToken, error) {
+	const op = "srvc.RefreshToken.ListByUser"
+
+	refreshTokens, err := r.refreshTokenRepo.ListByUser(ctx, user, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refreshTokens, nil
+}
+
+func (r *RefreshToken) MarkUsed(ctx context.Context, refreshToken *model.RefreshToken) error {
+	const op = "srvc.RefreshToken.MarkUsed"
+
+	err := r.refreshTokenRepo.MarkUsed(ctx, refreshToken, time.Now())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package srvc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"tech_check/internal/def"
+	"tech_check/internal/model"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Registration struct {
+	isEnabled      bool
+	allowedDomains []string
+	verifyURL      string
+	verifyTTL      time.Duration
+	tokenSigner    TokenSigner
+	userSrvc       UserSrvc
+	mailSrvc       MailSrvc
+}
+
+func NewRegistration(
+	isEnabled bool,
+	allowedDomains []string,
+	verifyURL string,
+	verifyTTLHour int,
+	tokenSigner TokenSigner,
+	userSrvc UserSrvc,
+	mailSrvc MailSrvc,
+) *Registration {
+	domains := make([]string, 0, len(allowedDomains))
+	for _, domain := range allowedDomains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	return &Registration{
+		isEnabled:      isEnabled,
+		allowedDomains: domains,
+		verifyURL:      verifyURL,
+		verifyTTL:      time.Duration(verifyTTLHour) * time.Hour,
+		tokenSigner:    tokenSigner,
+		userSrvc:       userSrvc,
+		mailSrvc:       mailSrvc,
+	}
+}
+
+func (r *Registration) Register(ctx context.Context, email, name, password string) (*model.User, error) {
+	const op = "srvc.Registration.Register"
+
+	if !r.isEnabled {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrRegistrationDisabled)
+	}
+
+	err := r.validateDomain(email)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := r.userSrvc.Register(ctx, email, name, password)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = r.sendVerification(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+func (r *Registration) Verify(ctx context.Context, token string) error {
+	const op = "srvc.Registration.Verify"
+
+	claims := jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(
+		token,
+		&claims,
+		r.tokenSigner.Key,
+		jwt.WithAudience(def.AudienceEmailVerification.String()),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, def.ErrInvalidVerifyToken)
+	}
+
+	user, err := r.userSrvc.GetByID(ctx, claims.Subject)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !user.EmailVerificationPending {
+		return nil
+	}
+
+	err = r.userSrvc.ConfirmEmail(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Registration) ResendVerification(ctx context.Context, user *model.User) error {
+	const op = "srvc.Registration.ResendVerification"
+
+	if !user.EmailVerificationPending {
+		return fmt.Errorf("%s: %w", op, def.ErrEmailAlreadyVerified)
+	}
+
+	err := r.sendVerification(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Registration) validateDomain(email string) error {
+	const op = "srvc.Registration.validateDomain"
+
+	if len(r.allowedDomains) == 0 {
+		return nil
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at == -1 || !slices.Contains(r.allowedDomains, strings.ToLower(email[at+1:])) {
+		return fmt.Errorf("%s: %w", op, def.ErrEmailDomainDenied)
+	}
+
+	return nil
+}
+
+// sendVerification mails a signed token instead of storing one, the
+// audience keeps it from being accepted as an access token.
+func (r *Registration) sendVerification(ctx context.Context, user *model.User) error {
+	const op = "srvc.Registration.sendVerification"
+
+	claims := jwt.RegisteredClaims{
+		Subject:   user.ID.Hex(),
+		Audience:  jwt.ClaimStrings{def.AudienceEmailVerification.String()},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(r.verifyTTL)),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	}
+	token, err := r.tokenSigner.Sign(claims)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = r.mailSrvc.Enqueue(ctx, user, def.MailVerifyEmail, map[string]string{
+		def.MailKeyLink: r.verifyURL + "?token=" + url.QueryEscape(token),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -5,6 +5,9 @@ import (
 	"tech_check/internal/def"
 	"tech_check/internal/dto"
 	"tech_check/internal/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type (
@@ -13,16 +16,49 @@ type (
 		Create(ctx context.Context, user *model.User) error
 		GetByID(ctx context.Context, id string) (*model.User, error)
 		Update(ctx context.Context, user *model.User) error
+		UpdatePassword(ctx context.Context, user *model.User) error
+		UpdateTwoFactor(ctx context.Context, user *model.User) error
+		UseTOTPStep(ctx context.Context, user *model.User, step int64) error
+		UseRecoveryCode(ctx context.Context, user *model.User, hash string) error
 		Delete(ctx context.Context, id string) error
 		IsExistsEmail(ctx context.Context, email string) (bool, error)
 		GetByEmail(ctx context.Context, email string) (*model.User, error)
-		HasPermission(ctx context.Context, user *model.User, permissionSlug string) (bool, error)
+		IncTokenVersion(ctx context.Context, user *model.User) error
 	}
 
 	RefreshTokenRepo interface {
 		Create(ctx context.Context, refreshToken *model.RefreshToken) error
 		GetByUserAndID(ctx context.Context, user *model.User, id string) (*model.RefreshToken, error)
 		DeleteByUser(ctx context.Context, user *model.User) error
+		DeleteFamily(ctx context.Context, refreshToken *model.RefreshToken) error
+		DeleteExpiredByUser(ctx context.Context, user *model.User, now time.Time) error
+		ListByUser(ctx context.Context, user *model.User, now time.Time) ([]model.RefreshToken, error)
+		MarkUsed(ctx context.Context, refreshToken *model.RefreshToken, now time.Time) error
+	}
+
+	PasswordResetRepo interface {
+		Create(ctx context.Context, passwordReset *model.PasswordReset) error
+		DeleteByUser(ctx context.Context, user *model.User) error
+		Consume(ctx context.Context, hash string, now time.Time) (*model.PasswordReset, error)
+	}
+
+	SecurityEventRepo interface {
+		Create(ctx context.Context, event *model.SecurityEvent) error
+	}
+
+	AuditLogRepo interface {
+		List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.AuditLog, *dto.Pagination, error)
+		Create(ctx context.Context, auditLog *model.AuditLog) error
+	}
+
+	LockoutRepo interface {
+		List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.Lockout, *dto.Pagination, error)
+		GetByID(ctx context.Context, id string) (*model.Lockout, error)
+		GetByKey(ctx context.Context, kind def.LockoutKind, key string) (*model.Lockout, error)
+		IncFailures(ctx context.Context, kind def.LockoutKind, key string, now, resetBefore time.Time) (*model.Lockout, error)
+		SetLockedUntil(ctx context.Context, lockout *model.Lockout, lockedUntil time.Time) error
+		Delete(ctx context.Context, id string) error
+		DeleteByKey(ctx context.Context, kind def.LockoutKind, key string) error
 	}
 
 	RoleRepo interface {
@@ -32,11 +68,15 @@ type (
 		Update(ctx context.Context, role *model.Role) error
 		Delete(ctx context.Context, id string) error
 		CountBySlug(ctx context.Context, slug string) (int, error)
+		ListWithAncestors(ctx context.Context, ids []primitive.ObjectID) ([]model.Role, error)
 	}
 
 	PermissionRepo interface {
 		List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.Permission, *dto.Pagination, error)
 		GetByID(ctx context.Context, id string) (*model.Permission, error)
+		CreateIfMissing(ctx context.Context, permission *model.Permission) (bool, error)
+		ListSlugs(ctx context.Context) ([]string, error)
+		ListByIDs(ctx context.Context, ids []primitive.ObjectID) ([]model.Permission, error)
 	}
 
 	CategoryRepo interface {
@@ -54,7 +94,13 @@ type (
 		GetByID(ctx context.Context, id string) (*model.Question, error)
 		Update(ctx context.Context, question *model.Question) error
 		Delete(ctx context.Context, id string) error
-		GetRandom(ctx context.Context, category *model.Category, grade def.GradeName, count int) ([]model.Question, error)
+		GetRandom(
+			ctx context.Context,
+			category *model.Category,
+			grade def.GradeName,
+			count int,
+			excludeIDs []primitive.ObjectID,
+		) ([]model.Question, error)
 	}
 
 	SessionRepo interface {
@@ -62,7 +108,18 @@ type (
 		Create(ctx context.Context, session *model.Session) error
 		GetByID(ctx context.Context, id string) (*model.Session, error)
 		Update(ctx context.Context, session *model.Session) error
+		UpdateEstimatedGrade(ctx context.Context, session *model.Session) error
+		Finish(ctx context.Context, session *model.Session) error
 		IsExistsActive(ctx context.Context, user *model.User) (bool, error)
+		ListExpired(ctx context.Context, now time.Time, count int) ([]model.Session, error)
+	}
+
+	SessionTemplateRepo interface {
+		List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.SessionTemplate, *dto.Pagination, error)
+		Create(ctx context.Context, template *model.SessionTemplate) error
+		GetByID(ctx context.Context, id string) (*model.SessionTemplate, error)
+		Update(ctx context.Context, template *model.SessionTemplate) error
+		Delete(ctx context.Context, id string) error
 	}
 
 	SessionQuestionRepo interface {
@@ -70,5 +127,16 @@ type (
 		Create(ctx context.Context, question *model.SessionQuestion) error
 		GetByID(ctx context.Context, session *model.Session, id string) (*model.SessionQuestion, error)
 		Update(ctx context.Context, question *model.SessionQuestion) error
+		UpdateEvaluation(ctx context.Context, question *model.SessionQuestion) error
+		SetOpenedAt(ctx context.Context, question *model.SessionQuestion) error
+		Expire(ctx context.Context, question *model.SessionQuestion) error
+		UpdateChoiceAnswer(ctx context.Context, question *model.SessionQuestion) error
+		StatsByQuestion(ctx context.Context, question *model.Question) (*dto.QuestionStats, error)
+	}
+
+	JobRepo interface {
+		Create(ctx context.Context, job *model.Job) error
+		ClaimNext(ctx context.Context, now, staleBefore time.Time) (*model.Job, error)
+		Update(ctx context.Context, job *model.Job) error
 	}
 )
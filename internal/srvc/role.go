@@ -3,24 +3,32 @@ package srvc
 import (
 	"context"
 	"fmt"
+	"tech_check/internal/def"
 	"tech_check/internal/dto"
 	"tech_check/internal/model"
 
 	"github.com/gosimple/slug"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Role struct {
-	roleRepo       RoleRepo
-	permissionSrvc PermissionSrvc
+	roleRepo                RoleRepo
+	permissionSrvc          PermissionSrvc
+	effectivePermissionSrvc EffectivePermissionSrvc
+	auditLogSrvc            AuditLogSrvc
 }
 
 func NewRole(
 	roleRepo RoleRepo,
 	permissionSrvc PermissionSrvc,
+	effectivePermissionSrvc EffectivePermissionSrvc,
+	auditLogSrvc AuditLogSrvc,
 ) *Role {
 	return &Role{
-		roleRepo:       roleRepo,
-		permissionSrvc: permissionSrvc,
+		roleRepo:                roleRepo,
+		permissionSrvc:          permissionSrvc,
+		effectivePermissionSrvc: effectivePermissionSrvc,
+		auditLogSrvc:            auditLogSrvc,
 	}
 }
 
@@ -35,7 +43,7 @@ func (r *Role) List(ctx context.Context, page, count int, filters, sorts map[str
 	return role, pagination, nil
 }
 
-func (r *Role) Create(ctx context.Context, name string) (*model.Role, error) {
+func (r *Role) Create(ctx context.Context, name string, requireTOTP bool) (*model.Role, error) {
 	const op = "srvc.Role.Create"
 
 	slug := slug.Make(name)
@@ -48,8 +56,9 @@ func (r *Role) Create(ctx context.Context, name string) (*model.Role, error) {
 	}
 
 	role := model.Role{
-		Name: name,
-		Slug: slug,
+		Name:        name,
+		Slug:        slug,
+		RequireTOTP: requireTOTP,
 	}
 
 	err = r.roleRepo.Create(ctx, &role)
@@ -57,6 +66,8 @@ func (r *Role) Create(ctx context.Context, name string) (*model.Role, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	r.auditLogSrvc.Record(ctx, def.AuditRoleCreate, def.AuditTargetRole, role.ID, nil, &role)
+
 	return &role, nil
 }
 
@@ -74,11 +85,25 @@ func (r *Role) GetByID(ctx context.Context, id string) (*model.Role, error) {
 func (r *Role) Delete(ctx context.Context, id string) error {
 	const op = "srvc.Role.Delete"
 
-	err := r.roleRepo.Delete(ctx, id)
+	role, err := r.roleRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	before, err := auditSnapshot(role)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = r.roleRepo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.effectivePermissionSrvc.InvalidateAll()
+
+	r.auditLogSrvc.Record(ctx, def.AuditRoleDelete, def.AuditTargetRole, role.ID, before, nil)
+
 	return nil
 }
 
@@ -106,12 +131,21 @@ func (r *Role) AddPermission(ctx context.Context, id, permissionID string) (*mod
 		return role, nil
 	}
 
+	before, err := auditSnapshot(role)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	role.PermissionIDs = append(role.PermissionIDs, permission.ID)
 	err = r.roleRepo.Update(ctx, role)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	r.effectivePermissionSrvc.InvalidateAll()
+
+	r.auditLogSrvc.Record(ctx, def.AuditRoleAddPermission, def.AuditTargetRole, role.ID, before, role)
+
 	return role, nil
 }
 
@@ -139,16 +173,25 @@ func (r *Role) RemovePermission(ctx context.Context, id, permissionID string) (*
 		return role, nil
 	}
 
+	before, err := auditSnapshot(role)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	role.PermissionIDs = append(role.PermissionIDs[:existsIdx], role.PermissionIDs[existsIdx+1:]...)
 	err = r.roleRepo.Update(ctx, role)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	r.effectivePermissionSrvc.InvalidateAll()
+
+	r.auditLogSrvc.Record(ctx, def.AuditRoleRemovePermission, def.AuditTargetRole, role.ID, before, role)
+
 	return role, nil
 }
 
-func (r *Role) Update(ctx context.Context, id, name string) (*model.Role, error) {
+func (r *Role) Update(ctx context.Context, id, name string, requireTOTP bool) (*model.Role, error) {
 	const op = "srvc.Role.Update"
 
 	role, err := r.roleRepo.GetByID(ctx, id)
@@ -156,11 +199,123 @@ func (r *Role) Update(ctx context.Context, id, name string) (*model.Role, error)
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	before, err := auditSnapshot(role)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	role.Name = name
+	role.RequireTOTP = requireTOTP
+	err = r.roleRepo.Update(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.effectivePermissionSrvc.InvalidateAll()
+
+	r.auditLogSrvc.Record(ctx, def.AuditRoleUpdate, def.AuditTargetRole, role.ID, before, role)
+
+	return role, nil
+}
+
+// AddParent makes the role inherit the permissions of the parent role and
+// refuses parents that already inherit from the role.
+func (r *Role) AddParent(ctx context.Context, id, parentID string) (*model.Role, error) {
+	const op = "srvc.Role.AddParent"
+
+	role, err := r.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	parent, err := r.roleRepo.GetByID(ctx, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, parentID := range role.ParentIDs {
+		if parentID == parent.ID {
+			return role, nil
+		}
+	}
+
+	ancestors, err := r.roleRepo.ListWithAncestors(ctx, []primitive.ObjectID{parent.ID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == role.ID {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrRoleCycle)
+		}
+	}
+
+	before, err := auditSnapshot(role)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	role.ParentIDs = append(role.ParentIDs, parent.ID)
+	err = r.roleRepo.Update(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.effectivePermissionSrvc.InvalidateAll()
+
+	r.auditLogSrvc.Record(ctx, def.AuditRoleAddParent, def.AuditTargetRole, role.ID, before, role)
+
+	return role, nil
+}
+
+func (r *Role) RemoveParent(ctx context.Context, id, parentID string) (*model.Role, error) {
+	const op = "srvc.Role.RemoveParent"
+
+	role, err := r.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	existsIdx := -1
+	for index, existingID := range role.ParentIDs {
+		if existingID.Hex() == parentID {
+			existsIdx = index
+			break
+		}
+	}
+	if existsIdx == -1 {
+		return role, nil
+	}
+
+	before, err := auditSnapshot(role)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	role.ParentIDs = append(role.ParentIDs[:existsIdx], role.ParentIDs[existsIdx+1:]...)
 	err = r.roleRepo.Update(ctx, role)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	r.effectivePermissionSrvc.InvalidateAll()
+
+	r.auditLogSrvc.Record(ctx, def.AuditRoleRemoveParent, def.AuditTargetRole, role.ID, before, role)
+
 	return role, nil
 }
+
+func (r *Role) ExpandPermissions(ctx context.Context, id string) (*dto.RolePermissions, error) {
+	const op = "srvc.Role.ExpandPermissions"
+
+	role, err := r.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	permissions, err := r.effectivePermissionSrvc.Expand(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return permissions, nil
+}
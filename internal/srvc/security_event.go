@@ -0,0 +1,42 @@
+package srvc
+
+import (
+	"context"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/model"
+)
+
+type SecurityEvent struct {
+	securityEventRepo SecurityEventRepo
+}
+
+func NewSecurityEvent(securityEventRepo SecurityEventRepo) *SecurityEvent {
+	return &SecurityEvent{
+		securityEventRepo: securityEventRepo,
+	}
+}
+
+func (s *SecurityEvent) Create(
+	ctx context.Context,
+	user *model.User,
+	eventType def.SecurityEventType,
+	ip, userAgent string,
+	details map[string]string,
+) (*model.SecurityEvent, error) {
+	const op = "srvc.SecurityEvent.Create"
+
+	event := model.SecurityEvent{
+		UserID:    user.ID,
+		Type:      eventType,
+		IP:        ip,
+		UserAgent: userAgent,
+		Details:   details,
+	}
+	err := s.securityEventRepo.Create(ctx, &event)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &event, nil
+}
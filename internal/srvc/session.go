@@ -2,33 +2,52 @@ package srvc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"tech_check/internal/def"
 	"tech_check/internal/dto"
 	"tech_check/internal/model"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Session struct {
 	count               int
+	timeLimitMinute     int
+	expireBatchCount    int
+	raiseGradeScore     int
+	keepGradeScore      int
 	sessionRepo         SessionRepo
 	categorySrvc        CategorySrvc
 	questionSrvc        QuestionSrvc
+	sessionTemplateSrvc SessionTemplateSrvc
 	sessionQuestionSrvc SessionQuestionSrvc
+	jobSrvc             JobSrvc
 }
 
 func NewSession(
+	timeLimitMinute int,
 	sessionRepo SessionRepo,
 	categorySrvc CategorySrvc,
 	questionSrvc QuestionSrvc,
+	sessionTemplateSrvc SessionTemplateSrvc,
 	sessionQuestionSrvc SessionQuestionSrvc,
+	jobSrvc JobSrvc,
 ) *Session {
 	return &Session{
 		count:               10,
+		timeLimitMinute:     timeLimitMinute,
+		expireBatchCount:    100,
+		raiseGradeScore:     80,
+		keepGradeScore:      50,
 		sessionRepo:         sessionRepo,
 		categorySrvc:        categorySrvc,
 		questionSrvc:        questionSrvc,
+		sessionTemplateSrvc: sessionTemplateSrvc,
 		sessionQuestionSrvc: sessionQuestionSrvc,
+		jobSrvc:             jobSrvc,
 	}
 }
 
@@ -43,9 +62,18 @@ func (s *Session) List(ctx context.Context, user *model.User, page, count int) (
 	return sessions, pagination, nil
 }
 
-func (s *Session) Create(ctx context.Context, user *model.User, categoryID, grade string) (*model.Session, error) {
+func (s *Session) Create(
+	ctx context.Context,
+	user *model.User,
+	categoryID, grade, templateID, mode string,
+	timeLimitMinute, questionTimeLimitSecond int,
+) (*model.Session, error) {
 	const op = "srvc.Session.Create"
 
+	if user.EmailVerificationPending {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrEmailNotVerified)
+	}
+
 	exists, err := s.sessionRepo.IsExistsActive(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -54,37 +82,81 @@ func (s *Session) Create(ctx context.Context, user *model.User, categoryID, grad
 		return nil, fmt.Errorf("%s: %w", op, def.ErrUserHasActiveSession)
 	}
 
-	category, err := s.categorySrvc.GetByID(ctx, categoryID)
+	modeObj, err := def.ValidateSessionMode(mode)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	questions, err := s.questionSrvc.GetRandom(ctx, category, grade, s.count)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+	session := model.Session{
+		UserID:                  user.ID,
+		Mode:                    modeObj,
+		QuestionTimeLimitSecond: questionTimeLimitSecond,
 	}
+	var questions []model.Question
 
-	if len(questions) != s.count {
-		return nil, fmt.Errorf("%s: %w", op, def.ErrQuestionNotEnough)
-	}
+	if templateID != "" {
+		if modeObj == def.SessionAdaptive {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidSessionMode)
+		}
 
-	gradeObj, err := def.ValidateGradeName(grade)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		template, err := s.sessionTemplateSrvc.GetByID(ctx, templateID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		questions, err = s.pickByTemplate(ctx, template)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		session.TemplateID = &template.ID
+		session.CategoryID, session.Grade = s.templateTarget(template)
+	} else {
+		category, err := s.categorySrvc.GetByID(ctx, categoryID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		gradeObj, err := def.ValidateGradeName(grade)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		questionCount := s.count
+		if modeObj == def.SessionAdaptive {
+			questionCount = 1
+		}
+
+		questions, err = s.pick(ctx, category, gradeObj, questionCount, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		session.CategoryID = category.ID
+		session.Grade = gradeObj
+		if modeObj == def.SessionAdaptive {
+			session.EstimatedGrade = gradeObj
+		}
 	}
 
-	session := model.Session{
-		UserID:     user.ID,
-		CategoryID: category.ID,
-		Grade:      gradeObj,
+	if timeLimitMinute == 0 {
+		timeLimitMinute = s.timeLimitMinute
+	}
+	if timeLimitMinute > 0 {
+		expiresAt := time.Now().Add(time.Duration(timeLimitMinute) * time.Minute)
+		session.ExpiresAt = &expiresAt
 	}
 	err = s.sessionRepo.Create(ctx, &session)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if modeObj == def.SessionAdaptive {
+		return &session, nil
+	}
+
 	for _, question := range questions {
-		_, err := s.sessionQuestionSrvc.Create(ctx, &session, question.Text)
+		_, err := s.sessionQuestionSrvc.Create(ctx, &session, &question)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -112,6 +184,87 @@ func (s *Session) GetByID(ctx context.Context, user *model.User, id string) (*mo
 	return session, nil
 }
 
+func (s *Session) Next(ctx context.Context, user *model.User, id string) (*model.SessionQuestion, error) {
+	const op = "srvc.Session.Next"
+
+	session, err := s.GetByID(ctx, user, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if session.Mode != def.SessionAdaptive {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrSessionNotAdaptive)
+	}
+
+	if session.ExpiresAt != nil && time.Now().After(*session.ExpiresAt) {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrSessionExpired)
+	}
+
+	questions, err := s.sessionQuestionSrvc.List(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(questions) > 0 {
+		last := &questions[len(questions)-1]
+		if last.Answer == "" && !last.EvaluationStatus.IsFinal() {
+			isExpired, err := s.sessionQuestionSrvc.Expire(ctx, session, last)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			if !isExpired {
+				question, err := s.sessionQuestionSrvc.Open(ctx, session, last.ID.Hex())
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", op, err)
+				}
+				return question, nil
+			}
+		}
+
+		if !last.EvaluationStatus.IsFinal() {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrEvaluationPending)
+		}
+	}
+
+	session.EstimatedGrade = s.estimateGrade(session, questions)
+	err = s.sessionRepo.UpdateEstimatedGrade(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(questions) >= s.count {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrSessionQuestionsOver)
+	}
+
+	category, err := s.categorySrvc.GetByID(ctx, session.CategoryID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	excludeIDs := make([]primitive.ObjectID, 0, len(questions))
+	for _, question := range questions {
+		excludeIDs = append(excludeIDs, question.QuestionID)
+	}
+
+	sources, err := s.pick(ctx, category, session.EstimatedGrade, 1, excludeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	question, err := s.sessionQuestionSrvc.Create(ctx, session, &sources[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	question, err = s.sessionQuestionSrvc.Open(ctx, session, question.ID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return question, nil
+}
+
 func (s *Session) Summarize(ctx context.Context, user *model.User, id string) (*model.Session, error) {
 	const op = "srvc.Session.Summarize"
 
@@ -120,31 +273,343 @@ func (s *Session) Summarize(ctx context.Context, user *model.User, id string) (*
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	err = s.finish(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+func (s *Session) FinishExpired(ctx context.Context) error {
+	const op = "srvc.Session.FinishExpired"
+
+	for {
+		sessions, err := s.sessionRepo.ListExpired(ctx, time.Now(), s.expireBatchCount)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, session := range sessions {
+			err = s.finish(ctx, &session)
+			if err != nil && !errors.Is(err, def.ErrSessionFinished) {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		if len(sessions) < s.expireBatchCount {
+			return nil
+		}
+	}
+}
+
+func (s *Session) finish(ctx context.Context, session *model.Session) error {
+	const op = "srvc.Session.finish"
+
 	now := time.Now()
-	session.Summary = "TODO: ai summary"
+	session.SummaryStatus = def.EvaluationPending
 	session.FinishedAt = &now
+	err := s.sessionRepo.Finish(ctx, session)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.jobSrvc.Enqueue(ctx, def.JobSummarizeSession, map[string]string{
+		def.JobKeySessionID: session.ID.Hex(),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Session) BuildReport(ctx context.Context, id string) error {
+	const op = "srvc.Session.BuildReport"
+
+	session, err := s.sessionRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	questions, err := s.sessionQuestionSrvc.List(ctx, session)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, question := range questions {
+		if question.Answer != "" && !question.EvaluationStatus.IsFinal() {
+			return fmt.Errorf("%s: %w", op, def.ErrEvaluationPending)
+		}
+	}
+
+	report, err := s.buildReport(ctx, session, questions)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	session.Report = report
+	session.Summary = s.narrate(session, report)
+	session.SummaryStatus = def.EvaluationDone
 	err = s.sessionRepo.Update(ctx, session)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return session, nil
+	return nil
+}
+
+func (s *Session) FailReport(ctx context.Context, id string) error {
+	const op = "srvc.Session.FailReport"
+
+	session, err := s.sessionRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	session.SummaryStatus = def.EvaluationFailed
+	err = s.sessionRepo.Update(ctx, session)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Session) EvaluateQuestion(ctx context.Context, id, questionID string) error {
+	const op = "srvc.Session.EvaluateQuestion"
+
+	session, err := s.sessionRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.sessionQuestionSrvc.Evaluate(ctx, session, questionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Session) FailQuestionEvaluation(ctx context.Context, id, questionID string) error {
+	const op = "srvc.Session.FailQuestionEvaluation"
+
+	session, err := s.sessionRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.sessionQuestionSrvc.FailEvaluation(ctx, session, questionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
 }
 
 func (s *Session) Cancel(ctx context.Context, user *model.User, id string) (*model.Session, error) {
 	const op = "srvc.Session.Cancel"
 
-	session,  err := s.GetByID(ctx, user, id)
+	session, err := s.GetByID(ctx, user, id)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	now := time.Now()
 	session.FinishedAt = &now
-	err = s.sessionRepo.Update(ctx, session)
+	err = s.sessionRepo.Finish(ctx, session)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return session, nil
 }
+
+func (s *Session) buildReport(ctx context.Context, session *model.Session, questions []model.SessionQuestion) (*model.SessionReport, error) {
+	const op = "srvc.Session.buildReport"
+
+	report := model.SessionReport{
+		Topics: []model.SessionTopic{},
+	}
+	topicIdx := make(map[primitive.ObjectID]int)
+	topicSums := []int{}
+	scoreSum := 0
+
+	for _, question := range questions {
+		categoryID := question.CategoryID
+		if categoryID.IsZero() {
+			categoryID = session.CategoryID
+		}
+
+		idx, ok := topicIdx[categoryID]
+		if !ok {
+			category, err := s.categorySrvc.GetByID(ctx, categoryID.Hex())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			idx = len(report.Topics)
+			topicIdx[categoryID] = idx
+			report.Topics = append(report.Topics, model.SessionTopic{
+				CategoryID: category.ID,
+				Name:       category.Name,
+			})
+			topicSums = append(topicSums, 0)
+		}
+
+		report.Total++
+		report.Topics[idx].Total++
+		if question.Answer != "" {
+			report.Answered++
+			report.Topics[idx].Answered++
+			scoreSum += question.Score
+			topicSums[idx] += question.Score
+		}
+	}
+
+	for idx := range report.Topics {
+		report.Topics[idx].Score = topicSums[idx] / report.Topics[idx].Total
+	}
+	if report.Total > 0 {
+		report.Score = scoreSum / report.Total
+	}
+
+	if session.Mode == def.SessionAdaptive {
+		session.EstimatedGrade = s.estimateGrade(session, questions)
+		report.RecommendedGrade = session.EstimatedGrade
+	} else {
+		report.RecommendedGrade = s.recommendGrade(session.Grade, report.Score)
+	}
+	report.Verdict = def.CompareGrades(report.RecommendedGrade, session.Grade)
+
+	return &report, nil
+}
+
+func (s *Session) recommendGrade(targeted def.GradeName, score int) def.GradeName {
+	switch {
+	case score >= s.raiseGradeScore:
+		return def.GradeByLevel(targeted.Level() + 1)
+	case score >= s.keepGradeScore:
+		return targeted
+	default:
+		return def.GradeByLevel(targeted.Level() - 1)
+	}
+}
+
+func (s *Session) pick(
+	ctx context.Context,
+	category *model.Category,
+	grade def.GradeName,
+	count int,
+	excludeIDs []primitive.ObjectID,
+) ([]model.Question, error) {
+	const op = "srvc.Session.pick"
+
+	questions, err := s.questionSrvc.GetRandom(ctx, category, grade.String(), count, excludeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(questions) < count {
+		return nil, fmt.Errorf("%s: %w", op, &def.QuestionNotEnoughError{
+			Category: category.Name,
+			Grade:    grade,
+			Want:     count,
+			Got:      len(questions),
+		})
+	}
+
+	return questions, nil
+}
+
+func (s *Session) pickByTemplate(ctx context.Context, template *model.SessionTemplate) ([]model.Question, error) {
+	const op = "srvc.Session.pickByTemplate"
+
+	questions := []model.Question{}
+	excludeIDs := []primitive.ObjectID{}
+	for _, bucket := range template.Buckets {
+		category, err := s.categorySrvc.GetByID(ctx, bucket.CategoryID.Hex())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		picked, err := s.pick(ctx, category, bucket.Grade, bucket.Count, excludeIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, question := range picked {
+			excludeIDs = append(excludeIDs, question.ID)
+		}
+		questions = append(questions, picked...)
+	}
+
+	return questions, nil
+}
+
+func (s *Session) templateTarget(template *model.SessionTemplate) (primitive.ObjectID, def.GradeName) {
+	var categoryID primitive.ObjectID
+	categoryCounts := make(map[primitive.ObjectID]int)
+	levelSum, total := 0, 0
+
+	for _, bucket := range template.Buckets {
+		categoryCounts[bucket.CategoryID] += bucket.Count
+		if categoryCounts[bucket.CategoryID] > categoryCounts[categoryID] {
+			categoryID = bucket.CategoryID
+		}
+
+		levelSum += bucket.Grade.Level() * bucket.Count
+		total += bucket.Count
+	}
+
+	if total == 0 {
+		return categoryID, def.GradeByLevel(0)
+	}
+
+	return categoryID, def.GradeByLevel((2*levelSum + total) / (2 * total))
+}
+
+func (s *Session) estimateGrade(session *model.Session, questions []model.SessionQuestion) def.GradeName {
+	if len(questions) == 0 {
+		return session.Grade
+	}
+
+	// an expired question is done without an answer and counts as 0
+	last := questions[len(questions)-1]
+	if last.EvaluationStatus != def.EvaluationDone {
+		return last.Grade
+	}
+
+	return s.recommendGrade(last.Grade, last.Score)
+}
+
+func (s *Session) narrate(session *model.Session, report *model.SessionReport) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Answered %d of %d questions with an overall score of %d/100. ", report.Answered, report.Total, report.Score)
+
+	if len(report.Topics) > 1 {
+		strongest, weakest := report.Topics[0], report.Topics[0]
+		for _, topic := range report.Topics[1:] {
+			if topic.Score > strongest.Score {
+				strongest = topic
+			}
+			if topic.Score < weakest.Score {
+				weakest = topic
+			}
+		}
+		fmt.Fprintf(&sb, "Strongest topic: %s (%d/100), weakest topic: %s (%d/100). ", strongest.Name, strongest.Score, weakest.Name, weakest.Score)
+	}
+
+	switch report.Verdict {
+	case def.VerdictAbove:
+		fmt.Fprintf(&sb, "The result exceeds the targeted %s level; %s is recommended.", session.Grade, report.RecommendedGrade)
+	case def.VerdictBelow:
+		fmt.Fprintf(&sb, "The result is below the targeted %s level; %s is recommended.", session.Grade, report.RecommendedGrade)
+	default:
+		fmt.Fprintf(&sb, "The result meets the targeted %s level.", session.Grade)
+	}
+
+	return sb.String()
+}
@@ -2,28 +2,72 @@ package srvc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
 	"tech_check/internal/model"
+	"time"
 )
 
 type SessionQuestion struct {
 	questionRepo SessionQuestionRepo
+	categorySrvc CategorySrvc
+	jobSrvc      JobSrvc
+	evaluator    Evaluator
+	codeRunner   CodeRunner
 }
 
 func NewSessionQuestion(
 	questionRepo SessionQuestionRepo,
+	categorySrvc CategorySrvc,
+	jobSrvc JobSrvc,
+	evaluator Evaluator,
+	codeRunner CodeRunner,
 ) *SessionQuestion {
 	return &SessionQuestion{
 		questionRepo: questionRepo,
+		categorySrvc: categorySrvc,
+		jobSrvc:      jobSrvc,
+		evaluator:    evaluator,
+		codeRunner:   codeRunner,
 	}
 }
 
-func (s *SessionQuestion) Create(ctx context.Context, session *model.Session, text string) (*model.SessionQuestion, error) {
+func (s *SessionQuestion) Create(ctx context.Context, session *model.Session, source *model.Question) (*model.SessionQuestion, error) {
 	const op = "srvc.SessionQuestion.Create"
 
+	questionType := source.Type
+	if questionType == "" {
+		questionType = def.QuestionText
+	}
+
+	options := make([]model.SessionOption, 0, len(source.Options))
+	correctOptionIDs := []string{}
+	for _, option := range source.Options {
+		options = append(options, model.SessionOption{
+			ID:   option.ID,
+			Text: option.Text,
+		})
+		if option.IsCorrect {
+			correctOptionIDs = append(correctOptionIDs, option.ID)
+		}
+	}
+
 	question := model.SessionQuestion{
-		SessionID: session.ID,
-		Text:      text,
+		SessionID:        session.ID,
+		QuestionID:       source.ID,
+		Text:             source.Text,
+		Grade:            source.Grade,
+		CategoryID:       source.CategoryID,
+		Type:             questionType,
+		Options:          options,
+		CorrectOptionIDs: correctOptionIDs,
+		Stub:             source.Code.Stub,
+		Tests:            source.Code.Tests,
+		Rubric:           source.Rubric,
+		SourceUpdatedAt:  source.UpdatedAt,
 	}
 	err := s.questionRepo.Create(ctx, &question)
 	if err != nil {
@@ -44,6 +88,30 @@ func (s *SessionQuestion) List(ctx context.Context, session *model.Session) ([]m
 	return questions, nil
 }
 
+// Preview lists the questions as the candidate may see them. Under a
+// per-question time limit the content of a question stays hidden until it
+// is opened, otherwise it could be read ahead of the clock starting.
+func (s *SessionQuestion) Preview(ctx context.Context, session *model.Session) ([]model.SessionQuestion, error) {
+	const op = "srvc.SessionQuestion.Preview"
+
+	questions, err := s.questionRepo.List(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if session.QuestionTimeLimitSecond > 0 {
+		for idx := range questions {
+			if questions[idx].OpenedAt == nil {
+				questions[idx].Text = ""
+				questions[idx].Options = []model.SessionOption{}
+				questions[idx].Stub = ""
+			}
+		}
+	}
+
+	return questions, nil
+}
+
 func (s *SessionQuestion) GetByID(ctx context.Context, session *model.Session, id string) (*model.SessionQuestion, error) {
 	const op = "srvc.SessionQuestion.GetByID"
 
@@ -55,20 +123,351 @@ func (s *SessionQuestion) GetByID(ctx context.Context, session *model.Session, i
 	return question, nil
 }
 
-func (s *SessionQuestion) Update(ctx context.Context, session *model.Session, id, answer string) (*model.SessionQuestion, error) {
+func (s *SessionQuestion) Open(ctx context.Context, session *model.Session, id string) (*model.SessionQuestion, error) {
+	const op = "srvc.SessionQuestion.Open"
+
+	question, err := s.GetByID(ctx, session, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if question.OpenedAt != nil {
+		return question, nil
+	}
+
+	now := time.Now()
+	question.OpenedAt = &now
+	err = s.questionRepo.SetOpenedAt(ctx, question)
+	if err != nil {
+		if !errors.Is(err, def.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		question, err = s.GetByID(ctx, session, id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return question, nil
+}
+
+// Expire closes an opened question whose time limit passed without an
+// answer: it is scored 0 and counts as evaluated. It reports false while
+// the question can still be answered.
+func (s *SessionQuestion) Expire(ctx context.Context, session *model.Session, question *model.SessionQuestion) (bool, error) {
+	const op = "srvc.SessionQuestion.Expire"
+
+	if question.OpenedAt == nil || !s.isExpired(session, question, time.Now()) {
+		return false, nil
+	}
+
+	question.Summary = "Time limit exceeded."
+	question.Score = 0
+	question.EvaluationStatus = def.EvaluationDone
+	err := s.questionRepo.Expire(ctx, question)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return true, nil
+}
+
+func (s *SessionQuestion) Update(
+	ctx context.Context,
+	session *model.Session,
+	id, answer, code string,
+	optionIDs []string,
+) (*model.SessionQuestion, error) {
 	const op = "srvc.SessionQuestion.Update"
 
+	now := time.Now()
+	if session.ExpiresAt != nil && now.After(*session.ExpiresAt) {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrSessionExpired)
+	}
+
 	question, err := s.GetByID(ctx, session, id)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if session.QuestionTimeLimitSecond > 0 {
+		if question.OpenedAt == nil {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrQuestionNotOpened)
+		}
+
+		if s.isExpired(session, question, now) {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrQuestionExpired)
+		}
+	}
+
+	if question.Type.IsChoice() {
+		if code != "" {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidAnswer)
+		}
+
+		if len(question.AnswerOptionIDs) > 0 {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrQuestionAnswered)
+		}
+
+		err = s.answerChoice(question, answer, optionIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = s.questionRepo.UpdateChoiceAnswer(ctx, question)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return question, nil
+	}
+
+	if question.Type == def.QuestionCode {
+		if code == "" || answer != "" || len(optionIDs) > 0 {
+			return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidAnswer)
+		}
+		answer = code
+	} else if answer == "" || code != "" || len(optionIDs) > 0 {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidAnswer)
+	}
+
 	question.Answer = answer
-	question.Summary = "TODO: ai summary"
+	question.AnswerOptionIDs = nil
+	question.Summary = ""
+	question.Score = 0
+	question.Strengths = nil
+	question.Weaknesses = nil
+	question.TestResults = nil
+	question.EvaluationStatus = def.EvaluationPending
 	err = s.questionRepo.Update(ctx, question)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	_, err = s.jobSrvc.Enqueue(ctx, def.JobEvaluateAnswer, map[string]string{
+		def.JobKeySessionID:  session.ID.Hex(),
+		def.JobKeyQuestionID: question.ID.Hex(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return question, nil
 }
+
+// Evaluate scores the current answer. When the candidate answers again
+// while the evaluation runs, the result is dropped and the job enqueued
+// for the newer answer takes over.
+func (s *SessionQuestion) Evaluate(ctx context.Context, session *model.Session, id string) error {
+	const op = "srvc.SessionQuestion.Evaluate"
+
+	question, err := s.GetByID(ctx, session, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	question.EvaluationStatus = def.EvaluationRunning
+	err = s.questionRepo.UpdateEvaluation(ctx, question)
+	if err != nil {
+		if errors.Is(err, def.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	evaluation, err := s.evaluate(ctx, session, question)
+	if err != nil {
+		question.EvaluationStatus = def.EvaluationPending
+		updateErr := s.questionRepo.UpdateEvaluation(ctx, question)
+		if updateErr != nil && !errors.Is(updateErr, def.ErrNotFound) {
+			return fmt.Errorf("%s: %w", op, updateErr)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	question.Summary = evaluation.Summary
+	question.Score = evaluation.Score
+	question.Strengths = evaluation.Strengths
+	question.Weaknesses = evaluation.Weaknesses
+	question.TestResults = evaluation.TestResults
+	question.EvaluationStatus = def.EvaluationDone
+	err = s.questionRepo.UpdateEvaluation(ctx, question)
+	if err != nil {
+		if errors.Is(err, def.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *SessionQuestion) FailEvaluation(ctx context.Context, session *model.Session, id string) error {
+	const op = "srvc.SessionQuestion.FailEvaluation"
+
+	question, err := s.GetByID(ctx, session, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	question.EvaluationStatus = def.EvaluationFailed
+	err = s.questionRepo.UpdateEvaluation(ctx, question)
+	if err != nil {
+		if errors.Is(err, def.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *SessionQuestion) StatsByQuestion(ctx context.Context, question *model.Question) (*dto.QuestionStats, error) {
+	const op = "srvc.SessionQuestion.StatsByQuestion"
+
+	stats, err := s.questionRepo.StatsByQuestion(ctx, question)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stats, nil
+}
+
+func (s *SessionQuestion) evaluate(ctx context.Context, session *model.Session, question *model.SessionQuestion) (*dto.Evaluation, error) {
+	const op = "srvc.SessionQuestion.evaluate"
+
+	if question.Type == def.QuestionCode {
+		evaluation, err := s.runCode(ctx, question)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return evaluation, nil
+	}
+
+	grade, categoryID := question.Grade, question.CategoryID
+	if categoryID.IsZero() {
+		grade, categoryID = session.Grade, session.CategoryID
+	}
+
+	category, err := s.categorySrvc.GetByID(ctx, categoryID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	evaluation, err := s.evaluator.Evaluate(ctx, &dto.EvaluationInput{
+		Question: question.Text,
+		Grade:    grade.String(),
+		Category: category.Name,
+		Answer:   question.Answer,
+		Rubric:   question.Rubric,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return evaluation, nil
+}
+
+func (s *SessionQuestion) runCode(ctx context.Context, question *model.SessionQuestion) (*dto.Evaluation, error) {
+	const op = "srvc.SessionQuestion.runCode"
+
+	run, err := s.codeRunner.Run(ctx, question.Answer, question.Tests)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	passed := 0
+	for _, result := range run.Results {
+		if result.Passed {
+			passed++
+		}
+	}
+
+	score := 0
+	if len(run.Results) > 0 {
+		score = 100 * passed / len(run.Results)
+	}
+
+	evaluation := dto.Evaluation{
+		Summary:     fmt.Sprintf("Passed %d of %d test cases.", passed, len(run.Results)),
+		Score:       score,
+		Strengths:   []string{},
+		Weaknesses:  []string{},
+		TestResults: run.Results,
+	}
+	if run.BuildOutput != "" {
+		evaluation.Summary = "The code could not be built: " + run.BuildOutput
+		evaluation.Weaknesses = append(evaluation.Weaknesses, "code does not compile")
+	}
+
+	return &evaluation, nil
+}
+
+func (s *SessionQuestion) isExpired(session *model.Session, question *model.SessionQuestion, now time.Time) bool {
+	if session.QuestionTimeLimitSecond <= 0 || question.OpenedAt == nil {
+		return false
+	}
+
+	deadline := question.OpenedAt.Add(time.Duration(session.QuestionTimeLimitSecond) * time.Second)
+
+	return now.After(deadline)
+}
+
+func (s *SessionQuestion) answerChoice(question *model.SessionQuestion, answer string, optionIDs []string) error {
+	if answer != "" || len(optionIDs) == 0 {
+		return def.ErrInvalidAnswer
+	}
+	if question.Type == def.QuestionSingleChoice && len(optionIDs) != 1 {
+		return def.ErrInvalidAnswer
+	}
+
+	optionTexts := make(map[string]string, len(question.Options))
+	for _, option := range question.Options {
+		optionTexts[option.ID] = option.Text
+	}
+
+	selected := make(map[string]bool, len(optionIDs))
+	ids := make([]string, 0, len(optionIDs))
+	texts := make([]string, 0, len(optionIDs))
+	for _, optionID := range optionIDs {
+		text, ok := optionTexts[optionID]
+		if !ok {
+			return def.ErrUnknownOption
+		}
+		if selected[optionID] {
+			continue
+		}
+		selected[optionID] = true
+		ids = append(ids, optionID)
+		texts = append(texts, text)
+	}
+
+	hits := 0
+	for _, optionID := range question.CorrectOptionIDs {
+		if selected[optionID] {
+			hits++
+		}
+	}
+	misses := len(selected) - hits
+
+	score := 0
+	if len(question.CorrectOptionIDs) > 0 {
+		score = max(0, 100*(hits-misses)/len(question.CorrectOptionIDs))
+	}
+
+	question.Answer = strings.Join(texts, "; ")
+	question.AnswerOptionIDs = ids
+	question.Summary = "Incorrect answer."
+	if score == 100 {
+		question.Summary = "Correct answer."
+	} else if score > 0 {
+		question.Summary = "Partially correct answer."
+	}
+	question.Score = score
+	question.Strengths = nil
+	question.Weaknesses = nil
+	question.EvaluationStatus = def.EvaluationDone
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package srvc
+
+import (
+	"context"
+	"fmt"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+)
+
+type SessionTemplate struct {
+	templateRepo SessionTemplateRepo
+	categorySrvc CategorySrvc
+}
+
+func NewSessionTemplate(templateRepo SessionTemplateRepo, categorySrvc CategorySrvc) *SessionTemplate {
+	return &SessionTemplate{
+		templateRepo: templateRepo,
+		categorySrvc: categorySrvc,
+	}
+}
+
+func (s *SessionTemplate) List(ctx context.Context, page, count int, filters, sorts map[string]string) ([]model.SessionTemplate, *dto.Pagination, error) {
+	const op = "srvc.SessionTemplate.List"
+
+	templates, pagination, err := s.templateRepo.List(ctx, page, count, filters, sorts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return templates, pagination, nil
+}
+
+func (s *SessionTemplate) Create(ctx context.Context, name, description string, buckets []dto.SessionBucket) (*model.SessionTemplate, error) {
+	const op = "srvc.SessionTemplate.Create"
+
+	bucketObjs, err := s.toBuckets(ctx, buckets)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	template := model.SessionTemplate{
+		Name:        name,
+		Description: description,
+		Buckets:     bucketObjs,
+	}
+	err = s.templateRepo.Create(ctx, &template)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &template, nil
+}
+
+func (s *SessionTemplate) GetByID(ctx context.Context, id string) (*model.SessionTemplate, error) {
+	const op = "srvc.SessionTemplate.GetByID"
+
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return template, nil
+}
+
+func (s *SessionTemplate) Update(ctx context.Context, id, name, description string, buckets []dto.SessionBucket) (*model.SessionTemplate, error) {
+	const op = "srvc.SessionTemplate.Update"
+
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bucketObjs, err := s.toBuckets(ctx, buckets)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	template.Name = name
+	template.Description = description
+	template.Buckets = bucketObjs
+	err = s.templateRepo.Update(ctx, template)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return template, nil
+}
+
+func (s *SessionTemplate) Delete(ctx context.Context, id string) error {
+	const op = "srvc.SessionTemplate.Delete"
+
+	err := s.templateRepo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *SessionTemplate) toBuckets(ctx context.Context, buckets []dto.SessionBucket) ([]model.SessionBucket, error) {
+	const op = "srvc.SessionTemplate.toBuckets"
+
+	bucketObjs := make([]model.SessionBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		category, err := s.categorySrvc.GetByID(ctx, bucket.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		grade, err := def.ValidateGradeName(bucket.Grade)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		bucketObjs = append(bucketObjs, model.SessionBucket{
+			CategoryID: category.ID,
+			Grade:      grade,
+			Count:      bucket.Count,
+		})
+	}
+
+	return bucketObjs, nil
+}
@@ -2,8 +2,13 @@ package srvc
 
 import (
 	"context"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
 	"tech_check/internal/model"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type (
@@ -11,12 +16,66 @@ type (
 		GetByEmail(ctx context.Context, email string) (*model.User, error)
 		GetByID(ctx context.Context, id string) (*model.User, error)
 		GetOrCreate(ctx context.Context, email, name, avatar string) (*model.User, error)
+		Register(ctx context.Context, email, name, password string) (*model.User, error)
+		ConfirmEmail(ctx context.Context, user *model.User) error
+		UpdateTwoFactor(ctx context.Context, user *model.User) error
+		ResetTwoFactor(ctx context.Context, user *model.User) error
+		UseTOTPStep(ctx context.Context, user *model.User, step int64) error
+		UseRecoveryCode(ctx context.Context, user *model.User, hash string) error
+		RevokeTokens(ctx context.Context, user *model.User) error
+		UpdatePassword(ctx context.Context, user *model.User, password string) error
+		EffectivePermissions(ctx context.Context, user *model.User) (*dto.EffectivePermissions, error)
 	}
 
 	RefreshTokenSrvc interface {
 		DeleteByUser(ctx context.Context, user *model.User) error
-		CreateByUser(ctx context.Context, user *model.User, ip, hash string, expiresAt time.Time) (*model.RefreshToken, error)
+		DeleteFamily(ctx context.Context, refreshToken *model.RefreshToken) error
+		DeleteExpiredByUser(ctx context.Context, user *model.User) error
+		CreateByUser(ctx context.Context, user *model.User, familyID primitive.ObjectID, ip, userAgent, hash string, expiresAt time.Time) (*model.RefreshToken, error)
 		GetByUserAndID(ctx context.Context, user *model.User, id string) (*model.RefreshToken, error)
+		ListByUser(ctx context.Context, user *model.User) ([]model.RefreshToken, error)
+		MarkUsed(ctx context.Context, refreshToken *model.RefreshToken) error
+	}
+
+	PasswordResetSrvc interface {
+		CreateByUser(ctx context.Context, user *model.User) (string, error)
+		Consume(ctx context.Context, token string) (*model.PasswordReset, error)
+	}
+
+	EffectivePermissionSrvc interface {
+		Get(ctx context.Context, user *model.User) (*dto.EffectivePermissions, error)
+		Expand(ctx context.Context, role *model.Role) (*dto.RolePermissions, error)
+		Invalidate(userID string)
+		InvalidateAll()
+	}
+
+	LockoutSrvc interface {
+		Check(ctx context.Context, email, ip string) error
+		Fail(ctx context.Context, email, ip string) error
+		CheckPasswordReset(ctx context.Context, email, ip string) error
+		FailPasswordReset(ctx context.Context, email, ip string) error
+		Reset(ctx context.Context, email string) error
+	}
+
+	SecurityEventSrvc interface {
+		Create(
+			ctx context.Context,
+			user *model.User,
+			eventType def.SecurityEventType,
+			ip, userAgent string,
+			details map[string]string,
+		) (*model.SecurityEvent, error)
+	}
+
+	AuditLogSrvc interface {
+		Record(
+			ctx context.Context,
+			action def.AuditAction,
+			targetType def.AuditTarget,
+			targetID primitive.ObjectID,
+			before map[string]interface{},
+			after interface{},
+		)
 	}
 
 	RoleSrvc interface {
@@ -32,10 +91,71 @@ type (
 	}
 
 	QuestionSrvc interface {
-		GetRandom(ctx context.Context, category *model.Category, grade string, count int) ([]model.Question, error)
+		GetRandom(
+			ctx context.Context,
+			category *model.Category,
+			grade string,
+			count int,
+			excludeIDs []primitive.ObjectID,
+		) ([]model.Question, error)
+	}
+
+	SessionTemplateSrvc interface {
+		GetByID(ctx context.Context, id string) (*model.SessionTemplate, error)
 	}
 
 	SessionQuestionSrvc interface {
-		Create(ctx context.Context, session *model.Session, text string) (*model.SessionQuestion, error)
+		Create(ctx context.Context, session *model.Session, source *model.Question) (*model.SessionQuestion, error)
+		List(ctx context.Context, session *model.Session) ([]model.SessionQuestion, error)
+		Open(ctx context.Context, session *model.Session, id string) (*model.SessionQuestion, error)
+		Expire(ctx context.Context, session *model.Session, question *model.SessionQuestion) (bool, error)
+		Evaluate(ctx context.Context, session *model.Session, id string) error
+		FailEvaluation(ctx context.Context, session *model.Session, id string) error
+		StatsByQuestion(ctx context.Context, question *model.Question) (*dto.QuestionStats, error)
+	}
+
+	JobSrvc interface {
+		Enqueue(ctx context.Context, jobType def.JobType, payload map[string]string) (*model.Job, error)
+	}
+
+	Evaluator interface {
+		Evaluate(ctx context.Context, input *dto.EvaluationInput) (*dto.Evaluation, error)
+	}
+
+	MailSrvc interface {
+		Enqueue(ctx context.Context, user *model.User, template def.MailTemplate, data map[string]string) error
+		Send(ctx context.Context, user *model.User, template def.MailTemplate, data map[string]string) error
+	}
+
+	MailRenderer interface {
+		Render(name def.MailTemplate, to string, data map[string]string) (*dto.Mail, error)
+	}
+
+	Mailer interface {
+		Send(ctx context.Context, mail *dto.Mail) error
+	}
+
+	TwoFactorSrvc interface {
+		Verify(ctx context.Context, user *model.User, code string) error
+	}
+
+	OneTimePassword interface {
+		NewSecret() (string, error)
+		URI(account, secret string) string
+		Validate(secret, code string, now time.Time, lastStep int64) (int64, bool)
+	}
+
+	OIDCVerifier interface {
+		Verify(ctx context.Context, provider, idToken string) (*dto.OIDCIdentity, error)
+	}
+
+	TokenSigner interface {
+		Sign(claims jwt.Claims) (string, error)
+		Key(token *jwt.Token) (interface{}, error)
+		JWKS() *dto.JWKS
+	}
+
+	CodeRunner interface {
+		Run(ctx context.Context, code string, tests []model.QuestionTest) (*dto.CodeRun, error)
 	}
 )
@@ -0,0 +1,204 @@
+package srvc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"tech_check/internal/def"
+	"tech_check/internal/dto"
+	"tech_check/internal/model"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type TwoFactor struct {
+	recoveryCodeCount int
+	oneTimePassword   OneTimePassword
+	userSrvc          UserSrvc
+	lockoutSrvc       LockoutSrvc
+}
+
+func NewTwoFactor(oneTimePassword OneTimePassword, userSrvc UserSrvc, lockoutSrvc LockoutSrvc) *TwoFactor {
+	return &TwoFactor{
+		recoveryCodeCount: 10,
+		oneTimePassword:   oneTimePassword,
+		userSrvc:          userSrvc,
+		lockoutSrvc:       lockoutSrvc,
+	}
+}
+
+// Enroll stores a new secret without enabling it, so an abandoned enrollment
+// never locks the user out. Confirm enables it once a code was accepted.
+// Both ask for the current password, a stolen access token alone must not
+// be enough to bind an authenticator.
+func (t *TwoFactor) Enroll(ctx context.Context, user *model.User, password, ip string) (*dto.TwoFactorEnrollment, error) {
+	const op = "srvc.TwoFactor.Enroll"
+
+	if user.TOTPEnabled {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrTwoFactorEnabled)
+	}
+
+	err := t.checkPassword(ctx, user, password, ip)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	secret, err := t.oneTimePassword.NewSecret()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	err = t.userSrvc.UpdateTwoFactor(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &dto.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    t.oneTimePassword.URI(user.Email, secret),
+	}, nil
+}
+
+func (t *TwoFactor) Confirm(ctx context.Context, user *model.User, password, code, ip string) (*dto.RecoveryCodes, error) {
+	const op = "srvc.TwoFactor.Confirm"
+
+	if user.TOTPEnabled {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrTwoFactorEnabled)
+	}
+	if user.TOTPSecret == "" {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrTwoFactorNotEnrolled)
+	}
+
+	err := t.checkPassword(ctx, user, password, ip)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	step, ok := t.oneTimePassword.Validate(user.TOTPSecret, code, time.Now(), user.TOTPLastStep)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, def.ErrInvalidTwoFactorCode)
+	}
+
+	codes, hashes, err := t.newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	user.RecoveryCodes = hashes
+	err = t.userSrvc.UpdateTwoFactor(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &dto.RecoveryCodes{Codes: codes}, nil
+}
+
+func (t *TwoFactor) Disable(ctx context.Context, user *model.User, code string) error {
+	const op = "srvc.TwoFactor.Disable"
+
+	err := t.Verify(ctx, user, code)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = t.userSrvc.ResetTwoFactor(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Verify accepts either a current TOTP code or one of the recovery codes,
+// a recovery code is removed once used. Both are consumed by conditional
+// updates, a request that loses the race gets ErrInvalidTwoFactorCode.
+func (t *TwoFactor) Verify(ctx context.Context, user *model.User, code string) error {
+	const op = "srvc.TwoFactor.Verify"
+
+	if !user.TOTPEnabled {
+		return fmt.Errorf("%s: %w", op, def.ErrTwoFactorNotEnrolled)
+	}
+
+	code = strings.TrimSpace(code)
+	var err error
+	step, ok := t.oneTimePassword.Validate(user.TOTPSecret, code, time.Now(), user.TOTPLastStep)
+	if ok {
+		err = t.userSrvc.UseTOTPStep(ctx, user, step)
+	} else {
+		err = t.userSrvc.UseRecoveryCode(ctx, user, t.hash(code))
+	}
+	if err != nil {
+		if errors.Is(err, def.ErrNotFound) {
+			return fmt.Errorf("%s: %w", op, def.ErrInvalidTwoFactorCode)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// checkPassword counts wrong passwords against the lockout like a login.
+// Accounts created by an identity provider have no password to check.
+func (t *TwoFactor) checkPassword(ctx context.Context, user *model.User, password, ip string) error {
+	const op = "srvc.TwoFactor.checkPassword"
+
+	if user.Password == "" {
+		return nil
+	}
+
+	err := t.lockoutSrvc.Check(ctx, user.Email, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	if err != nil {
+		if !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		lockErr := t.lockoutSrvc.Fail(ctx, user.Email, ip)
+		if lockErr != nil {
+			return fmt.Errorf("%s: %w", op, lockErr)
+		}
+		return fmt.Errorf("%s: %w", op, def.ErrInvalidCredentials)
+	}
+
+	return nil
+}
+
+func (t *TwoFactor) newRecoveryCodes() ([]string, []string, error) {
+	const op = "srvc.TwoFactor.newRecoveryCodes"
+
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, 0, t.recoveryCodeCount)
+	hashes := make([]string, 0, t.recoveryCodeCount)
+	for i := 0; i < t.recoveryCodeCount; i++ {
+		random := make([]byte, 5)
+		_, err := rand.Read(random)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		raw := strings.ToLower(encoding.EncodeToString(random))
+		code := raw[:4] + "-" + raw[4:]
+		codes = append(codes, code)
+		hashes = append(hashes, t.hash(code))
+	}
+
+	return codes, hashes, nil
+}
+
+func (t *TwoFactor) hash(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(code)))
+	return hex.EncodeToString(sum[:])
+}
@@ -12,17 +12,29 @@ import (
 )
 
 type User struct {
-	userRepo UserRepo
-	roleSrvc RoleSrvc
+	userRepo                UserRepo
+	roleSrvc                RoleSrvc
+	categorySrvc            CategorySrvc
+	effectivePermissionSrvc EffectivePermissionSrvc
+	refreshTokenSrvc        RefreshTokenSrvc
+	auditLogSrvc            AuditLogSrvc
 }
 
 func NewUser(
 	userRepo UserRepo,
 	roleSrvc RoleSrvc,
+	categorySrvc CategorySrvc,
+	effectivePermissionSrvc EffectivePermissionSrvc,
+	refreshTokenSrvc RefreshTokenSrvc,
+	auditLogSrvc AuditLogSrvc,
 ) *User {
 	return &User{
-		userRepo: userRepo,
-		roleSrvc: roleSrvc,
+		userRepo:                userRepo,
+		roleSrvc:                roleSrvc,
+		categorySrvc:            categorySrvc,
+		effectivePermissionSrvc: effectivePermissionSrvc,
+		refreshTokenSrvc:        refreshTokenSrvc,
+		auditLogSrvc:            auditLogSrvc,
 	}
 }
 
@@ -40,6 +52,44 @@ func (u *User) List(ctx context.Context, page, count int, filters, sorts map[str
 func (u *User) Create(ctx context.Context, email, name, password string) (*model.User, error) {
 	const op = "srvc.User.Create"
 
+	user, err := u.create(ctx, email, name, password, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	u.auditLogSrvc.Record(ctx, def.AuditUserCreate, def.AuditTargetUser, user.ID, nil, user)
+
+	return user, nil
+}
+
+// Register creates a self-registered user whose email still has to be
+// confirmed, users created by admins or fixtures are trusted as is.
+func (u *User) Register(ctx context.Context, email, name, password string) (*model.User, error) {
+	const op = "srvc.User.Register"
+
+	user, err := u.create(ctx, email, name, password, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+func (u *User) ConfirmEmail(ctx context.Context, user *model.User) error {
+	const op = "srvc.User.ConfirmEmail"
+
+	user.EmailVerificationPending = false
+	err := u.userRepo.Update(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (u *User) create(ctx context.Context, email, name, password string, isVerificationPending bool) (*model.User, error) {
+	const op = "srvc.User.create"
+
 	exists, err := u.userRepo.IsExistsEmail(ctx, email)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -54,9 +104,10 @@ func (u *User) Create(ctx context.Context, email, name, password string) (*model
 	}
 
 	user := model.User{
-		Email:    email,
-		Name:     name,
-		Password: string(passwordHash),
+		Email:                    email,
+		Name:                     name,
+		Password:                 string(passwordHash),
+		EmailVerificationPending: isVerificationPending,
 	}
 
 	err = u.userRepo.Create(ctx, &user)
@@ -72,8 +123,17 @@ func (u *User) GetOrCreate(ctx context.Context, email, name, avatar string) (*mo
 
 	user, err := u.GetByEmail(ctx, email)
 	if err == nil {
+		if user.EmailVerificationPending {
+			err = u.claim(ctx, user)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
 		user.Name = name
 		user.Avatar = avatar
+		// identity providers only vouch for verified addresses
+		user.EmailVerificationPending = false
 
 		err = u.userRepo.Update(ctx, user)
 		if err != nil {
@@ -100,6 +160,36 @@ func (u *User) GetOrCreate(ctx context.Context, email, name, avatar string) (*mo
 	return user, nil
 }
 
+// claim hands an account registered with an unverified email over to the
+// owner of the address: whoever registered it set the password and may hold
+// sessions, so the password is cleared and every token is revoked.
+func (u *User) claim(ctx context.Context, user *model.User) error {
+	const op = "srvc.User.claim"
+
+	user.Password = ""
+	err := u.userRepo.UpdatePassword(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = u.ResetTwoFactor(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = u.refreshTokenSrvc.DeleteByUser(ctx, user)
+	if err != nil && !errors.Is(err, def.ErrNotFound) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = u.RevokeTokens(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (u *User) GetByID(ctx context.Context, id string) (*model.User, error) {
 	const op = "srvc.User.GetByID"
 
@@ -128,14 +218,95 @@ func (u *User) Update(ctx context.Context, id, name string) (*model.User, error)
 	return user, nil
 }
 
+func (u *User) UpdatePassword(ctx context.Context, user *model.User, password string) error {
+	const op = "srvc.User.UpdatePassword"
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	user.Password = string(passwordHash)
+	err = u.userRepo.UpdatePassword(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (u *User) UseTOTPStep(ctx context.Context, user *model.User, step int64) error {
+	const op = "srvc.User.UseTOTPStep"
+
+	err := u.userRepo.UseTOTPStep(ctx, user, step)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (u *User) UseRecoveryCode(ctx context.Context, user *model.User, hash string) error {
+	const op = "srvc.User.UseRecoveryCode"
+
+	err := u.userRepo.UseRecoveryCode(ctx, user, hash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (u *User) UpdateTwoFactor(ctx context.Context, user *model.User) error {
+	const op = "srvc.User.UpdateTwoFactor"
+
+	err := u.userRepo.UpdateTwoFactor(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetTwoFactor turns 2FA off and drops the secret and recovery codes, for
+// when the account changes hands and the old factors must not carry over.
+func (u *User) ResetTwoFactor(ctx context.Context, user *model.User) error {
+	const op = "srvc.User.ResetTwoFactor"
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	user.RecoveryCodes = nil
+	err := u.userRepo.UpdateTwoFactor(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (u *User) Delete(ctx context.Context, id string) error {
 	const op = "srvc.User.Delete"
 
-	err := u.userRepo.Delete(ctx, id)
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	before, err := auditSnapshot(user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = u.userRepo.Delete(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	u.effectivePermissionSrvc.Invalidate(id)
+
+	u.auditLogSrvc.Record(ctx, def.AuditUserDelete, def.AuditTargetUser, user.ID, before, nil)
+
 	return nil
 }
 
@@ -150,7 +321,9 @@ func (u *User) GetByEmail(ctx context.Context, email string) (*model.User, error
 	return user, nil
 }
 
-func (u *User) AddRole(ctx context.Context, id, roleID string) (*model.User, error) {
+// AddRole grants the role globally, or only for one category when
+// categoryID is set.
+func (u *User) AddRole(ctx context.Context, id, roleID, categoryID string) (*model.User, error) {
 	const op = "srvc.User.AddRole"
 
 	user, err := u.userRepo.GetByID(ctx, id)
@@ -163,27 +336,50 @@ func (u *User) AddRole(ctx context.Context, id, roleID string) (*model.User, err
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	isUnique := true
-	for _, roleID := range user.RoleIDs {
-		if roleID == role.ID {
-			isUnique = false
-			break
-		}
+	before, err := auditSnapshot(user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if !isUnique {
-		return user, nil
+
+	if categoryID != "" {
+		category, err := u.categorySrvc.GetByID(ctx, categoryID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, scopedRole := range user.ScopedRoles {
+			if scopedRole.RoleID == role.ID && scopedRole.CategoryID == category.ID {
+				return user, nil
+			}
+		}
+
+		user.ScopedRoles = append(user.ScopedRoles, model.ScopedRole{
+			RoleID:     role.ID,
+			CategoryID: category.ID,
+		})
+	} else {
+		for _, roleID := range user.RoleIDs {
+			if roleID == role.ID {
+				return user, nil
+			}
+		}
+
+		user.RoleIDs = append(user.RoleIDs, role.ID)
 	}
 
-	user.RoleIDs = append(user.RoleIDs, role.ID)
 	err = u.userRepo.Update(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	u.effectivePermissionSrvc.Invalidate(user.ID.Hex())
+
+	u.auditLogSrvc.Record(ctx, def.AuditUserAddRole, def.AuditTargetUser, user.ID, before, user)
+
 	return user, nil
 }
 
-func (u *User) RemoveRole(ctx context.Context, id, roleID string) (*model.User, error) {
+func (u *User) RemoveRole(ctx context.Context, id, roleID, categoryID string) (*model.User, error) {
 	const op = "srvc.User.RemoveRole"
 
 	user, err := u.userRepo.GetByID(ctx, id)
@@ -196,33 +392,142 @@ func (u *User) RemoveRole(ctx context.Context, id, roleID string) (*model.User,
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	before, err := auditSnapshot(user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	existsIdx := -1
-	for index, roleID := range user.RoleIDs {
-		if roleID == role.ID {
-			existsIdx = index
-			break
+	if categoryID != "" {
+		for index, scopedRole := range user.ScopedRoles {
+			if scopedRole.RoleID == role.ID && scopedRole.CategoryID.Hex() == categoryID {
+				existsIdx = index
+				break
+			}
 		}
-	}
-	if existsIdx == -1 {
-		return user, nil
+		if existsIdx == -1 {
+			return user, nil
+		}
+
+		user.ScopedRoles = append(user.ScopedRoles[:existsIdx], user.ScopedRoles[existsIdx+1:]...)
+	} else {
+		for index, roleID := range user.RoleIDs {
+			if roleID == role.ID {
+				existsIdx = index
+				break
+			}
+		}
+		if existsIdx == -1 {
+			return user, nil
+		}
+
+		user.RoleIDs = append(user.RoleIDs[:existsIdx], user.RoleIDs[existsIdx+1:]...)
 	}
 
-	user.RoleIDs = append(user.RoleIDs[:existsIdx], user.RoleIDs[existsIdx+1:]...)
 	err = u.userRepo.Update(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	u.effectivePermissionSrvc.Invalidate(user.ID.Hex())
+
+	u.auditLogSrvc.Record(ctx, def.AuditUserRemoveRole, def.AuditTargetUser, user.ID, before, user)
+
+	err = u.RevokeTokens(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return user, nil
 }
 
-func (u *User) HasPermission(ctx context.Context, user *model.User, permissionSlug string) (bool, error) {
+// HasPermission checks a global grant, or a grant scoped to categoryID when
+// it is set. It also refuses access while the user holds a role that
+// requires two-factor authentication but has not enabled it yet.
+func (u *User) HasPermission(ctx context.Context, user *model.User, permissionSlug, categoryID string) (bool, error) {
 	const op = "srvc.User.HasPermission"
 
-	has, err := u.userRepo.HasPermission(ctx, user, permissionSlug)
+	permissions, err := u.effectivePermissionSrvc.Get(ctx, user)
 	if err != nil {
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
 
+	has := false
+	for _, slug := range permissions.Slugs {
+		if def.PermissionSlug(slug).Matches(def.PermissionSlug(permissionSlug)) {
+			has = true
+			break
+		}
+	}
+	if !has && categoryID != "" {
+		for slug, categoryIDs := range permissions.Scoped {
+			if !def.PermissionSlug(slug).Matches(def.PermissionSlug(permissionSlug)) {
+				continue
+			}
+			for _, scopedCategoryID := range categoryIDs {
+				if scopedCategoryID == categoryID {
+					has = true
+					break
+				}
+			}
+		}
+	}
+
+	if has && permissions.RequiresTOTP && !user.TOTPEnabled {
+		return false, fmt.Errorf("%s: %w", op, def.ErrTwoFactorRequired)
+	}
+
 	return has, nil
 }
+
+// PermissionCategoryIDs returns the categories the permission is granted
+// for, isGlobal means it is granted for every category.
+func (u *User) PermissionCategoryIDs(ctx context.Context, user *model.User, permissionSlug string) ([]string, bool, error) {
+	const op = "srvc.User.PermissionCategoryIDs"
+
+	permissions, err := u.effectivePermissionSrvc.Get(ctx, user)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if permissions.RequiresTOTP && !user.TOTPEnabled {
+		return []string{}, false, nil
+	}
+
+	for _, slug := range permissions.Slugs {
+		if def.PermissionSlug(slug).Matches(def.PermissionSlug(permissionSlug)) {
+			return nil, true, nil
+		}
+	}
+
+	categoryIDs := []string{}
+	for slug, scopedCategoryIDs := range permissions.Scoped {
+		if def.PermissionSlug(slug).Matches(def.PermissionSlug(permissionSlug)) {
+			categoryIDs = append(categoryIDs, scopedCategoryIDs...)
+		}
+	}
+
+	return categoryIDs, false, nil
+}
+
+func (u *User) EffectivePermissions(ctx context.Context, user *model.User) (*dto.EffectivePermissions, error) {
+	const op = "srvc.User.EffectivePermissions"
+
+	permissions, err := u.effectivePermissionSrvc.Get(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return permissions, nil
+}
+
+func (u *User) RevokeTokens(ctx context.Context, user *model.User) error {
+	const op = "srvc.User.RevokeTokens"
+
+	err := u.userRepo.IncTokenVersion(ctx, user)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP implements RFC 6238 with the parameters every authenticator app
+// supports: HMAC-SHA1, 6 digits and a 30 second period.
+type TOTP struct {
+	issuer       string
+	period       int64
+	digits       int
+	skew         int64
+	secretLength int
+}
+
+func New(issuer string) *TOTP {
+	return &TOTP{
+		issuer:       issuer,
+		period:       30,
+		digits:       6,
+		skew:         1,
+		secretLength: 20,
+	}
+}
+
+func (t *TOTP) NewSecret() (string, error) {
+	const op = "totp.TOTP.NewSecret"
+
+	random := make([]byte, t.secretLength)
+	_, err := rand.Read(random)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return encoding.EncodeToString(random), nil
+}
+
+func (t *TOTP) URI(account, secret string) string {
+	label := url.PathEscape(t.issuer) + ":" + url.PathEscape(account)
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", t.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(t.digits))
+	query.Set("period", fmt.Sprint(t.period))
+
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Validate accepts codes of the neighbouring steps to tolerate clock drift.
+// Steps up to lastStep were already used and are rejected, the matched step
+// is returned so the caller can store it.
+func (t *TOTP) Validate(secret, code string, now time.Time, lastStep int64) (int64, bool) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != t.digits {
+		return 0, false
+	}
+
+	current := now.Unix() / t.period
+	for step := current - t.skew; step <= current+t.skew; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(t.code(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+func (t *TOTP) code(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < t.digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", t.digits, value%modulo)
+}
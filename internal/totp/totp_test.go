@@ -0,0 +1,132 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 key of RFC 6238 appendix B, "12345678901234567890".
+var rfcSecret = encoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	totp := New("tech_check")
+	totp.digits = 8
+	key := []byte("12345678901234567890")
+
+	for _, tt := range tests {
+		got := totp.code(key, tt.unix/totp.period)
+		if got != tt.code {
+			t.Errorf("code(%d) = %s, want %s", tt.unix, got, tt.code)
+		}
+
+		step, ok := totp.Validate(rfcSecret, tt.code, time.Unix(tt.unix, 0), 0)
+		if !ok || step != tt.unix/totp.period {
+			t.Errorf("Validate(%d) = %d, %v, want %d, true", tt.unix, step, ok, tt.unix/totp.period)
+		}
+	}
+}
+
+func TestTOTPValidateSixDigits(t *testing.T) {
+	totp := New("tech_check")
+
+	// the last six digits of the 8 digit vector for 1111111109
+	_, ok := totp.Validate(rfcSecret, "081804", time.Unix(1111111109, 0), 0)
+	if !ok {
+		t.Error("Validate() rejected a valid code")
+	}
+
+	_, ok = totp.Validate(rfcSecret, "07081804", time.Unix(1111111109, 0), 0)
+	if ok {
+		t.Error("Validate() accepted a code of the wrong length")
+	}
+}
+
+func TestTOTPValidateSkew(t *testing.T) {
+	totp := New("tech_check")
+	key := []byte("12345678901234567890")
+	now := time.Unix(1111111109, 0)
+	current := now.Unix() / totp.period
+
+	tests := []struct {
+		name string
+		step int64
+		ok   bool
+	}{
+		{name: "previous step", step: current - 1, ok: true},
+		{name: "current step", step: current, ok: true},
+		{name: "next step", step: current + 1, ok: true},
+		{name: "two steps behind", step: current - 2, ok: false},
+		{name: "two steps ahead", step: current + 2, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := totp.Validate(rfcSecret, totp.code(key, tt.step), now, 0)
+			if ok != tt.ok {
+				t.Fatalf("Validate() ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && step != tt.step {
+				t.Errorf("Validate() step = %d, want %d", step, tt.step)
+			}
+		})
+	}
+}
+
+func TestTOTPValidateStepReuse(t *testing.T) {
+	totp := New("tech_check")
+	key := []byte("12345678901234567890")
+	now := time.Unix(1111111109, 0)
+	current := now.Unix() / totp.period
+	code := totp.code(key, current)
+
+	step, ok := totp.Validate(rfcSecret, code, now, 0)
+	if !ok {
+		t.Fatal("Validate() rejected a fresh code")
+	}
+
+	_, ok = totp.Validate(rfcSecret, code, now, step)
+	if ok {
+		t.Error("Validate() accepted the code of a used step")
+	}
+
+	_, ok = totp.Validate(rfcSecret, totp.code(key, current-1), now, step)
+	if ok {
+		t.Error("Validate() accepted the code of a step before the used one")
+	}
+
+	_, ok = totp.Validate(rfcSecret, totp.code(key, current+1), now, step)
+	if !ok {
+		t.Error("Validate() rejected the code of a step after the used one")
+	}
+}
+
+func TestTOTPValidateSecretCase(t *testing.T) {
+	totp := New("tech_check")
+	secret, err := totp.NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := encoding.DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	_, ok := totp.Validate(strings.ToLower(secret), totp.code(key, now.Unix()/totp.period), now, 0)
+	if !ok {
+		t.Error("Validate() rejected a lowercase secret")
+	}
+}
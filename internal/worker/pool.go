@@ -0,0 +1,158 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"tech_check/internal/def"
+	"tech_check/internal/model"
+	"time"
+)
+
+type (
+	Handler struct {
+		Run  func(ctx context.Context, job *model.Job) error
+		Dead func(ctx context.Context, job *model.Job) error
+	}
+
+	Pool struct {
+		count        int
+		pollInterval time.Duration
+		jobTimeout   time.Duration
+		jobSrvc      JobSrvc
+		lg           *slog.Logger
+		handlers     map[def.JobType]Handler
+		cancel       context.CancelFunc
+		wg           sync.WaitGroup
+	}
+)
+
+func NewPool(count int, jobSrvc JobSrvc, lg *slog.Logger) *Pool {
+	return &Pool{
+		count:        count,
+		pollInterval: time.Second,
+		jobTimeout:   2 * time.Minute,
+		jobSrvc:      jobSrvc,
+		lg:           lg,
+		handlers:     make(map[def.JobType]Handler),
+	}
+}
+
+func (p *Pool) Register(jobType def.JobType, handler Handler) {
+	p.handlers[jobType] = handler
+}
+
+func (p *Pool) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	for i := 0; i < p.count; i++ {
+		p.wg.Add(1)
+		go p.work(ctx)
+	}
+}
+
+func (p *Pool) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+}
+
+func (p *Pool) work(ctx context.Context) {
+	defer p.wg.Done()
+
+	for {
+		processed := p.processNext(ctx)
+		if processed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+func (p *Pool) processNext(ctx context.Context) bool {
+	const op = "worker.Pool.processNext"
+
+	if ctx.Err() != nil {
+		return false
+	}
+
+	job, err := p.jobSrvc.Claim(ctx)
+	if err != nil {
+		if !errors.Is(err, def.ErrNotFound) && ctx.Err() == nil {
+			p.lg.Error(fmt.Errorf("%s: %w", op, err).Error())
+		}
+		return false
+	}
+
+	lg := p.lg.With(
+		slog.String("job_id", job.ID.Hex()),
+		slog.String("job_type", job.Type.String()),
+		slog.Int("attempt", job.Attempts),
+	)
+
+	// jobs are finished with a detached context so that shutdown does not leave them running
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.jobTimeout)
+	defer cancel()
+
+	err = p.run(jobCtx, job)
+	if err == nil {
+		err = p.jobSrvc.Complete(jobCtx, job)
+		if err != nil {
+			lg.Error(fmt.Errorf("%s: %w", op, err).Error())
+		}
+		lg.Debug("job done")
+		return true
+	}
+
+	lg.Warn(fmt.Errorf("%s: %w", op, err).Error())
+
+	isDead, err := p.jobSrvc.Fail(jobCtx, job, err)
+	if err != nil {
+		lg.Error(fmt.Errorf("%s: %w", op, err).Error())
+		return true
+	}
+
+	if isDead {
+		lg.Error("job moved to dead letter")
+		handler, ok := p.handlers[job.Type]
+		if ok && handler.Dead != nil {
+			err = handler.Dead(jobCtx, job)
+			if err != nil {
+				lg.Error(fmt.Errorf("%s: %w", op, err).Error())
+			}
+		}
+	}
+
+	return true
+}
+
+func (p *Pool) run(ctx context.Context, job *model.Job) (err error) {
+	const op = "worker.Pool.run"
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: panic: %v", op, r)
+		}
+	}()
+
+	handler, ok := p.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("%s: %w: %s", op, def.ErrUnknownJobType, job.Type)
+	}
+
+	err = handler.Run(ctx, job)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type (
+	Task struct {
+		Name     string
+		Interval time.Duration
+		Run      func(ctx context.Context) error
+	}
+
+	Scheduler struct {
+		lg     *slog.Logger
+		tasks  []Task
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	}
+)
+
+func NewScheduler(lg *slog.Logger) *Scheduler {
+	return &Scheduler{
+		lg: lg,
+	}
+}
+
+func (s *Scheduler) Register(task Task) {
+	s.tasks = append(s.tasks, task)
+}
+
+func (s *Scheduler) Start() {
+	const op = "worker.Scheduler.Start"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, task := range s.tasks {
+		if task.Interval <= 0 {
+			s.lg.Warn(fmt.Sprintf("%s: %s: interval must be positive, task disabled", op, task.Name))
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.loop(ctx, task)
+	}
+}
+
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, task Task) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx, task)
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, task Task) {
+	const op = "worker.Scheduler.run"
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.lg.Error(fmt.Sprintf("%s: %s: panic: %v", op, task.Name, r))
+		}
+	}()
+
+	err := task.Run(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.lg.Error(fmt.Errorf("%s: %s: %w", op, task.Name, err).Error())
+	}
+}
@@ -0,0 +1,14 @@
+package worker
+
+import (
+	"context"
+	"tech_check/internal/model"
+)
+
+type (
+	JobSrvc interface {
+		Claim(ctx context.Context) (*model.Job, error)
+		Complete(ctx context.Context, job *model.Job) error
+		Fail(ctx context.Context, job *model.Job, cause error) (bool, error)
+	}
+)